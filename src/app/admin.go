@@ -0,0 +1,209 @@
+package app
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/latoken/bridge-backend-service/src/common"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+const (
+	adminActionResend        = "RESEND_PROPOSAL"
+	adminActionFail          = "FAIL_SWAP"
+	adminActionRefund        = "REFUND_SWAP"
+	adminActionRequeueUpdate = "REQUEUE_UPDATE"
+	adminActionResetCursor   = "RESET_CURSOR"
+	adminActionReparse       = "REPARSE_QUARANTINED"
+	adminActionSetMode       = "SET_MODE"
+)
+
+// adminRequest is body of admin action, all fields are optional unless action requires them
+type adminRequest struct {
+	Reason string `json:"reason"`
+	Height int64  `json:"height"`
+	Mode   string `json:"mode"`
+}
+
+func (a *App) setAdminRouters() {
+	admin := a.router.PathPrefix("/admin").Subrouter()
+	admin.Use(a.adminAuth)
+	admin.HandleFunc("/actions", a.AdminActionsHandler).Methods("GET")
+	admin.HandleFunc("/mode", a.SetModeHandler).Methods("POST")
+	admin.HandleFunc("/swaps/{swap_id}/resend", a.ResendProposalHandler).Methods("POST")
+	admin.HandleFunc("/swaps/{swap_id}/fail", a.FailSwapHandler).Methods("POST")
+	admin.HandleFunc("/swaps/{swap_id}/refund", a.RefundSwapHandler).Methods("POST")
+	admin.HandleFunc("/swaps/{swap_id}/requeue-update", a.RequeueUpdateHandler).Methods("POST")
+	admin.HandleFunc("/chains/{chain}/cursor", a.ResetCursorHandler).Methods("POST")
+	admin.HandleFunc("/quarantined-logs/reparse", a.ReparseQuarantinedLogsHandler).Methods("POST")
+	admin.HandleFunc("/quarantined-logs/{id}/reparse", a.ReparseQuarantinedLogHandler).Methods("POST")
+}
+
+// adminAuth checks bearer token of admin api, api is disabled when token is not configured
+func (a *App) adminAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if a.admin == nil || a.admin.Token == "" {
+			common.ResponJSON(w, http.StatusForbidden, createNewError("admin api is disabled", "admin token is not configured"))
+			return
+		}
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(a.admin.Token)) != 1 {
+			common.ResponJSON(w, http.StatusUnauthorized, createNewError("unauthorized", "invalid admin token"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// AdminActionsHandler returns the last admin actions
+func (a *App) AdminActionsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid limit", err.Error()))
+		return
+	}
+
+	actions, err := a.relayer.GetAdminActions(limit)
+	if err != nil {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("get admin actions from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, actions)
+}
+
+// ResendProposalHandler sends proposal of the swap again
+func (a *App) ResendProposalHandler(w http.ResponseWriter, r *http.Request) {
+	swapID := mux.Vars(r)["swap_id"]
+	a.adminAction(w, r, adminActionResend, swapID, false, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.ResendProposal(swapID, audit)
+	})
+}
+
+// FailSwapHandler marks the swap failed, reason is required
+func (a *App) FailSwapHandler(w http.ResponseWriter, r *http.Request) {
+	swapID := mux.Vars(r)["swap_id"]
+	a.adminAction(w, r, adminActionFail, swapID, true, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.FailSwap(swapID, audit)
+	})
+}
+
+// RefundSwapHandler marks the swap refunded, reason is required
+func (a *App) RefundSwapHandler(w http.ResponseWriter, r *http.Request) {
+	swapID := mux.Vars(r)["swap_id"]
+	a.adminAction(w, r, adminActionRefund, swapID, true, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.RefundSwap(swapID, audit)
+	})
+}
+
+// RequeueUpdateHandler sends failed update of the swap to lachain again
+func (a *App) RequeueUpdateHandler(w http.ResponseWriter, r *http.Request) {
+	swapID := mux.Vars(r)["swap_id"]
+	a.adminAction(w, r, adminActionRequeueUpdate, swapID, false, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.RequeueUpdate(swapID, audit)
+	})
+}
+
+// ResetCursorHandler moves watcher of the chain to height from body, reason is required
+func (a *App) ResetCursorHandler(w http.ResponseWriter, r *http.Request) {
+	chain := strings.ToUpper(mux.Vars(r)["chain"])
+	a.adminAction(w, r, adminActionResetCursor, chain, true, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.ResetCursor(chain, req.Height)
+	})
+}
+
+// SetModeHandler switches operating mode of relayer to mode from body, reason is required
+func (a *App) SetModeHandler(w http.ResponseWriter, r *http.Request) {
+	a.adminAction(w, r, adminActionSetMode, "relayer", true, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return nil, a.relayer.SetMode(strings.ToLower(req.Mode))
+	})
+}
+
+// ReparseQuarantinedLogsHandler re-parses quarantined logs, ?chain= filters by chain
+func (a *App) ReparseQuarantinedLogsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid limit", err.Error()))
+		return
+	}
+
+	chain := r.URL.Query().Get("chain")
+	a.adminAction(w, r, adminActionReparse, chain, false, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return a.relayer.ReparseQuarantinedLogs(chain, limit)
+	})
+}
+
+// ReparseQuarantinedLogHandler re-parses quarantined log by id
+func (a *App) ReparseQuarantinedLogHandler(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid id", err.Error()))
+		return
+	}
+
+	a.adminAction(w, r, adminActionReparse, mux.Vars(r)["id"], false, func(req *adminRequest, audit *storage.AdminAction) (interface{}, error) {
+		return a.relayer.ReparseQuarantinedLog(id)
+	})
+}
+
+// adminAction runs action on target and records it for audit with its result,
+// action changing status of the swap records successful audit in its own db transaction
+func (a *App) adminAction(w http.ResponseWriter, r *http.Request, action, target string, reasonRequired bool,
+	run func(req *adminRequest, audit *storage.AdminAction) (interface{}, error)) {
+	req := &adminRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid request body", err.Error()))
+		return
+	}
+	if reasonRequired && strings.TrimSpace(req.Reason) == "" {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid request body", "reason is required"))
+		return
+	}
+
+	params, _ := json.Marshal(struct {
+		Query  string `json:"query,omitempty"`
+		Height int64  `json:"height,omitempty"`
+		Mode   string `json:"mode,omitempty"`
+	}{r.URL.RawQuery, req.Height, req.Mode})
+	audit := &storage.AdminAction{
+		Action: action,
+		Target: target,
+		Params: string(params),
+		Reason: req.Reason,
+		Actor:  adminActor(r),
+		Result: "SUCCESS",
+	}
+
+	result, err := run(req, audit)
+	if err != nil {
+		audit.ID = 0
+		audit.Result = "FAILED"
+		audit.Error = err.Error()
+		a.relayer.AuditAdminAction(audit)
+		common.ResponJSON(w, http.StatusConflict, createNewError(fmt.Sprintf("%s %s", strings.ToLower(action), target), err.Error()))
+		return
+	}
+	if audit.ID == 0 {
+		a.relayer.AuditAdminAction(audit)
+	}
+	a.logger.Warnf("admin action %s on %s by %s, reason: %s", action, target, audit.Actor, audit.Reason)
+
+	if result == nil {
+		result = audit
+	}
+	common.ResponJSON(w, http.StatusOK, result)
+}
+
+// adminActor is operator name from X-Admin-Actor header or address of the client
+func adminActor(r *http.Request) string {
+	if actor := r.Header.Get("X-Admin-Actor"); actor != "" {
+		return actor
+	}
+	return r.RemoteAddr
+}
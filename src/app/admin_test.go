@@ -0,0 +1,65 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/latoken/bridge-backend-service/src/models"
+)
+
+func TestAdminAuth(t *testing.T) {
+	tests := []struct {
+		name   string
+		admin  *models.AdminConfig
+		header string
+		want   int
+	}{
+		{"admin api is not configured", nil, "Bearer secret", http.StatusForbidden},
+		{"token is not configured", &models.AdminConfig{}, "Bearer ", http.StatusForbidden},
+		{"no header", &models.AdminConfig{Token: "secret"}, "", http.StatusUnauthorized},
+		{"wrong token", &models.AdminConfig{Token: "secret"}, "Bearer secreT", http.StatusUnauthorized},
+		{"token prefix", &models.AdminConfig{Token: "secret"}, "Bearer secre", http.StatusUnauthorized},
+		{"valid token", &models.AdminConfig{Token: "secret"}, "Bearer secret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &App{admin: tt.admin}
+			handler := a.adminAuth(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/admin/mode", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Fatalf("got status %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestAdminRoutesRequireToken(t *testing.T) {
+	a := &App{router: mux.NewRouter(), admin: &models.AdminConfig{Token: "secret"}}
+	a.setAdminRouters()
+
+	for _, path := range []string{
+		"/admin/quarantined-logs/reparse",
+		"/admin/quarantined-logs/1/reparse",
+		"/admin/swaps/0x01/refund",
+		"/admin/mode",
+	} {
+		t.Run(path, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			a.router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, path, nil))
+			if rec.Code != http.StatusUnauthorized {
+				t.Fatalf("got status %d, want %d", rec.Code, http.StatusUnauthorized)
+			}
+		})
+	}
+}
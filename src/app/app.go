@@ -12,6 +12,7 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/gorm"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/sirupsen/logrus"
 )
 
@@ -21,18 +22,21 @@ type App struct {
 	router  *mux.Router
 	server  *http.Server
 	relayer *rlr.BridgeSRV
+	admin   *models.AdminConfig
 }
 
 // NewApp is initializes the app
-func NewApp(logger *logrus.Logger, addr string, db *gorm.DB,
+func NewApp(logger *logrus.Logger, addr string, db *gorm.DB, dbURL string,
 	laCfg *models.WorkerConfig, chainCfgs []*models.WorkerConfig, chainFetCfgs []*models.FetcherConfig,
-	resourceIDs []*storage.ResourceId) *App {
+	resourceIDs []*storage.ResourceId, alertsCfg *models.AlertsConfig, slaCfg *models.SLAConfig,
+	adminCfg *models.AdminConfig, mode string) *App {
 	// create new app
 	inst := &App{
 		logger:  logger,
 		router:  mux.NewRouter(),
 		server:  &http.Server{Addr: addr},
-		relayer: rlr.CreateNewBridgeSRV(logger, db, laCfg, chainCfgs, chainFetCfgs, resourceIDs),
+		relayer: rlr.CreateNewBridgeSRV(logger, db, dbURL, laCfg, chainCfgs, chainFetCfgs, resourceIDs, alertsCfg, slaCfg, mode),
+		admin:   adminCfg,
 	}
 	// set router
 	inst.router = mux.NewRouter()
@@ -54,13 +58,27 @@ func (a *App) Get(path string, f func(w http.ResponseWriter, r *http.Request)) {
 	a.router.HandleFunc(path, f).Methods("GET")
 }
 
+// Post wraps the router for POST method
+func (a *App) Post(path string, f func(w http.ResponseWriter, r *http.Request)) {
+	a.router.HandleFunc(path, f).Methods("POST")
+}
+
 func (a *App) setRouters() {
 	a.Get("/", a.Endpoints)
 	a.Get("/status", a.StatusHandler)
+	a.Get("/healthz", a.LivenessHandler)
+	a.Get("/readyz", a.ReadinessHandler)
 	a.Get("/gas-price/{chain}", a.GasPriceHandler)
 	a.Get("/tx-sent/{tx_hash}", a.TxSentHandler)
-	// a.Get("/resend_tx/{id}", a.ResendTxHandler)
-	// a.Get("/set_mode/{mode}", a.SetModeHandler)
+	a.Get("/swaps", a.SwapsHandler)
+	a.Get("/swaps/stream", a.SwapStreamHandler)
+	a.Get("/swaps/by-deposit/{tx_hash}", a.SwapByDepositHandler)
+	a.Get("/swaps/{swap_id}", a.SwapHandler)
+	a.Get("/security-events", a.SecurityEventsHandler)
+	a.Get("/stuck-swaps", a.StuckSwapsHandler)
+	a.router.Handle("/metrics", promhttp.Handler()).Methods("GET")
+	a.Get("/quarantined-logs", a.QuarantinedLogsHandler)
+	a.setAdminRouters()
 }
 
 // Run the app on it's router
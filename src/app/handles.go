@@ -2,10 +2,16 @@ package app
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/mux"
 	"github.com/latoken/bridge-backend-service/src/common"
+	"github.com/latoken/bridge-backend-service/src/models"
+	rlr "github.com/latoken/bridge-backend-service/src/service"
 )
 
 const numPerPage = 100
@@ -17,8 +23,27 @@ func (a *App) Endpoints(w http.ResponseWriter, r *http.Request) {
 	}{
 		Endpoints: []string{
 			"/status",
+			"/healthz",
+			"/readyz",
 			"/gas-price/{chain}",
 			"/tx-sent/{tx_hash}",
+			"/swaps",
+			"/swaps/{swap_id}",
+			"/swaps/stream",
+			"/swaps/by-deposit/{tx_hash}",
+			"/security-events",
+			"/stuck-swaps",
+			"/metrics",
+			"/quarantined-logs",
+			"GET /admin/actions",
+			"POST /admin/mode",
+			"POST /admin/swaps/{swap_id}/resend",
+			"POST /admin/swaps/{swap_id}/fail",
+			"POST /admin/swaps/{swap_id}/refund",
+			"POST /admin/swaps/{swap_id}/requeue-update",
+			"POST /admin/chains/{chain}/cursor",
+			"POST /admin/quarantined-logs/reparse",
+			"POST /admin/quarantined-logs/{id}/reparse",
 		},
 	}
 
@@ -43,6 +68,21 @@ func (a *App) StatusHandler(w http.ResponseWriter, r *http.Request) {
 	common.ResponJSON(w, http.StatusOK, status)
 }
 
+// LivenessHandler reports that process is alive and serves requests
+func (a *App) LivenessHandler(w http.ResponseWriter, r *http.Request) {
+	common.ResponJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// ReadinessHandler reports checks of dependencies, 503 if any of them fails
+func (a *App) ReadinessHandler(w http.ResponseWriter, r *http.Request) {
+	readiness := a.relayer.Readiness()
+	if !readiness.Ready {
+		common.ResponJSON(w, http.StatusServiceUnavailable, readiness)
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, readiness)
+}
+
 func (a *App) GasPriceHandler(w http.ResponseWriter, r *http.Request) {
 	msg := mux.Vars(r)["chain"]
 	v := r.URL.Query().Get("v")
@@ -74,6 +114,17 @@ func (a *App) TxSentHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// v=2 returns tx with its status and revert reason instead of hash only
+	if r.URL.Query().Get("v") == "2" {
+		txSent, err := a.relayer.GetTxSentDetails(txHash)
+		if err != nil {
+			common.ResponJSON(w, http.StatusNotFound, createNewError("get tx sent from database", err.Error()))
+			return
+		}
+		common.ResponJSON(w, http.StatusOK, txSent)
+		return
+	}
+
 	txSent, err := a.relayer.GetTxSent(txHash)
 	if err != nil {
 		common.ResponJSON(w, http.StatusNotFound, createNewError("get tx sent from database", err.Error()))
@@ -82,3 +133,129 @@ func (a *App) TxSentHandler(w http.ResponseWriter, r *http.Request) {
 
 	common.ResponJSON(w, http.StatusOK, txSent)
 }
+
+// SwapsHandler returns page of swaps filtered by
+// sender, receiver, address(sender or receiver), origin_chain, destination_chain, resource_id,
+// status(comma separated), from and to(unix seconds), ordered by create time(sort=asc|desc, desc by default)
+func (a *App) SwapsHandler(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := &models.SwapsQuery{
+		Sender:           params.Get("sender"),
+		Receiver:         params.Get("receiver"),
+		Address:          params.Get("address"),
+		OriginChain:      params.Get("origin_chain"),
+		DestinationChain: params.Get("destination_chain"),
+		ResourceID:       params.Get("resource_id"),
+		Cursor:           params.Get("cursor"),
+		Desc:             params.Get("sort") != "asc",
+	}
+	if v := params.Get("status"); v != "" {
+		query.Statuses = strings.Split(v, ",")
+	}
+
+	var err error
+	if query.Limit, err = parseLimit(r); err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid limit", err.Error()))
+		return
+	}
+	if query.From, err = parseTime(params.Get("from")); err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid from", err.Error()))
+		return
+	}
+	if query.To, err = parseTime(params.Get("to")); err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid to", err.Error()))
+		return
+	}
+
+	page, err := a.relayer.SearchSwaps(query)
+	if errors.Is(err, rlr.ErrInvalidSwapsQuery) {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid query", err.Error()))
+		return
+	} else if err != nil {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("get swaps from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, page)
+}
+
+// SwapHandler returns swap with every related tx by swap id
+func (a *App) SwapHandler(w http.ResponseWriter, r *http.Request) {
+	swap, err := a.relayer.GetSwap(mux.Vars(r)["swap_id"])
+	if err != nil {
+		common.ResponJSON(w, http.StatusNotFound, createNewError("get swap from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, swap)
+}
+
+// SwapByDepositHandler returns swap with every related tx by hash of deposit tx
+func (a *App) SwapByDepositHandler(w http.ResponseWriter, r *http.Request) {
+	swap, err := a.relayer.GetSwapByDepositTxHash(mux.Vars(r)["tx_hash"])
+	if err != nil {
+		common.ResponJSON(w, http.StatusNotFound, createNewError("get swap from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, swap)
+}
+
+// SecurityEventsHandler returns the last admin actions on bridge contracts, ?chain= filters by chain
+func (a *App) SecurityEventsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid limit", err.Error()))
+		return
+	}
+
+	events, err := a.relayer.GetSecurityEvents(r.URL.Query().Get("chain"), limit)
+	if err != nil {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("get security events from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, events)
+}
+
+// StuckSwapsHandler returns swaps not finished within SLA of their route
+func (a *App) StuckSwapsHandler(w http.ResponseWriter, r *http.Request) {
+	common.ResponJSON(w, http.StatusOK, a.relayer.GetStuckSwaps())
+}
+
+// QuarantinedLogsHandler returns logs waiting for re-parse, ?chain= filters by chain
+func (a *App) QuarantinedLogsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, err := parseLimit(r)
+	if err != nil {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid limit", err.Error()))
+		return
+	}
+
+	logs, err := a.relayer.GetQuarantinedLogs(r.URL.Query().Get("chain"), limit)
+	if err != nil {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("get quarantined logs from database", err.Error()))
+		return
+	}
+	common.ResponJSON(w, http.StatusOK, logs)
+}
+
+// parseTime returns unix seconds, zero if v is empty
+func parseTime(v string) (int64, error) {
+	if v == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(v, 10, 64)
+}
+
+// parseLimit returns ?limit= of the request, numPerPage by default
+func parseLimit(r *http.Request) (int, error) {
+	v := r.URL.Query().Get("limit")
+	if v == "" {
+		return numPerPage, nil
+	}
+
+	limit, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, err
+	}
+	if limit <= 0 || limit > numPerPage {
+		return 0, fmt.Errorf("limit must be in range 1..%d", numPerPage)
+	}
+	return limit, nil
+}
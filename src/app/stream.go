@@ -0,0 +1,60 @@
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/common"
+	rlr "github.com/latoken/bridge-backend-service/src/service"
+)
+
+// interval of keep-alive comments, so proxies don't close idle stream
+const streamKeepAlive = 15 * time.Second
+
+// SwapStreamHandler pushes status changes of swaps as server-sent events,
+// ?swap_id= and ?address=(sender or receiver) select swaps
+func (a *App) SwapStreamHandler(w http.ResponseWriter, r *http.Request) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("streaming is not supported", ""))
+		return
+	}
+
+	sub, err := a.relayer.SubscribeSwaps(r.URL.Query().Get("swap_id"), r.URL.Query().Get("address"))
+	if errors.Is(err, rlr.ErrInvalidSwapsQuery) {
+		common.ResponJSON(w, http.StatusBadRequest, createNewError("invalid query", err.Error()))
+		return
+	} else if err != nil {
+		common.ResponJSON(w, http.StatusInternalServerError, createNewError("subscribe to swaps", err.Error()))
+		return
+	}
+	defer a.relayer.Stream.Unsubscribe(sub)
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+		case update := <-sub.Updates:
+			data, err := json.Marshal(update)
+			if err != nil {
+				a.logger.Errorf("marshal swap update error, err = %v", err)
+				continue
+			}
+			fmt.Fprintf(w, "id: %s-%d\nevent: swap\ndata: %s\n\n", update.SwapID, update.UpdateTime, data)
+		}
+		flusher.Flush()
+	}
+}
@@ -11,6 +11,12 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 )
 
+const (
+	defaultRetryTimeout  = 1800
+	defaultRetryAttempts = 1
+	defaultRetryBackoff  = 1
+)
+
 // Reads Service params from config.json
 func (v *viperConfig) ReadServiceConfig() string {
 	return fmt.Sprintf("%s:%s", v.GetString("service.host"), v.GetString("service.port"))
@@ -54,24 +60,116 @@ func (v *viperConfig) ReadWorkersConfig() []*models.WorkerConfig {
 // readETHWorkerConfig reads ethereum chain worker params from config.json
 func (v *viperConfig) readWorkerConfig(name string) *models.WorkerConfig {
 	return &models.WorkerConfig{
-		NetworkType:           v.GetString(fmt.Sprintf("workers.%s.type", name)),
-		ChainName:             strings.ToUpper(name),
-		WorkerAddr:            common.HexToAddress(v.GetString(fmt.Sprintf("workers.%s.worker_addr", name))),
-		PrivateKey:            v.GetString(fmt.Sprintf("workers.%s.private_key", name)),
+		NetworkType: v.GetString(fmt.Sprintf("workers.%s.type", name)),
+		ChainName:   strings.ToUpper(name),
+		WorkerAddr:  common.HexToAddress(v.GetString(fmt.Sprintf("workers.%s.worker_addr", name))),
+		PrivateKey:  v.GetString(fmt.Sprintf("workers.%s.private_key", name)),
+		Signer: models.SignerConfig{
+			Type:           v.GetString(fmt.Sprintf("workers.%s.signer.type", name)),
+			KeystorePath:   v.GetString(fmt.Sprintf("workers.%s.signer.keystore_path", name)),
+			PassphraseEnv:  v.GetString(fmt.Sprintf("workers.%s.signer.passphrase_env", name)),
+			PassphraseFile: v.GetString(fmt.Sprintf("workers.%s.signer.passphrase_file", name)),
+			URL:            v.GetString(fmt.Sprintf("workers.%s.signer.url", name)),
+			TokenEnv:       v.GetString(fmt.Sprintf("workers.%s.signer.token_env", name)),
+			Timeout:        v.GetInt64(fmt.Sprintf("workers.%s.signer.timeout", name)),
+		},
 		Provider:              v.GetString(fmt.Sprintf("workers.%s.provider", name)),
+		Providers:             v.GetStringSlice(fmt.Sprintf("workers.%s.providers", name)),
+		ProviderMaxLag:        v.GetInt64(fmt.Sprintf("workers.%s.provider_max_lag", name)),
+		ProviderCheckInterval: v.GetInt64(fmt.Sprintf("workers.%s.provider_check_interval", name)),
+		Subscribe:             v.GetBool(fmt.Sprintf("workers.%s.subscribe", name)),
+		MaxBlockRange:         v.GetInt64(fmt.Sprintf("workers.%s.max_block_range", name)),
+		MaxLag:                v.GetInt64(fmt.Sprintf("workers.%s.max_lag", name)),
+		GasPriceMaxAge:        v.GetInt64(fmt.Sprintf("workers.%s.gas_price_max_age", name)),
 		ContractAddr:          common.HexToAddress(v.GetString(fmt.Sprintf("workers.%s.contract_addr", name))),
 		AMUSDTContractAddr:    common.HexToAddress(v.GetString(fmt.Sprintf("workers.%s.amUSDT_token_addr", name))),
 		AmTokenHandlerAddress: common.HexToAddress(v.GetString(fmt.Sprintf("workers.%s.amToken_handler_addr", name))),
 		NativeResourceID:      v.GetString(fmt.Sprintf("workers.%s.native_resource_id", name)),
 		GasLimit:              v.GetInt64(fmt.Sprintf("workers.%s.gas_limit", name)),
+		GasMargin:             v.GetInt64(fmt.Sprintf("workers.%s.gas_margin", name)),
 		GasPrice:              big.NewInt(v.GetInt64(fmt.Sprintf("workers.%s.gas_price", name))),
+		TxType:                v.GetString(fmt.Sprintf("workers.%s.tx_type", name)),
+		MaxFeePerGas:          big.NewInt(v.GetInt64(fmt.Sprintf("workers.%s.max_fee_per_gas", name))),
+		MaxPriorityFeePerGas:  big.NewInt(v.GetInt64(fmt.Sprintf("workers.%s.max_priority_fee_per_gas", name))),
+		MaxGasPrice:           big.NewInt(v.GetInt64(fmt.Sprintf("workers.%s.max_gas_price", name))),
+		GasPriceBump:          v.GetInt64(fmt.Sprintf("workers.%s.gas_price_bump", name)),
+		ReplaceTimeout:        v.GetInt64(fmt.Sprintf("workers.%s.replace_timeout", name)),
+		Retry:                 v.readRetryConfig(name),
 		FetchInterval:         v.GetInt64(fmt.Sprintf("workers.%s.fetch_interval", name)),
 		ConfirmNum:            v.GetInt64(fmt.Sprintf("workers.%s.confirm_num", name)),
+		BlockHistory:          v.GetInt64(fmt.Sprintf("workers.%s.block_history", name)),
 		StartBlockHeight:      v.GetInt64(fmt.Sprintf("workers.%s.start_block_height", name)),
 		DestinationChainID:    v.GetString(fmt.Sprintf("workers.%s.dest_id", name)),
 	}
 }
 
+// readRetryConfig reads auto-retry policies of the chain, policy of failure kind
+// overrides common one of the chain
+func (v *viperConfig) readRetryConfig(name string) models.RetryConfig {
+	return models.RetryConfig{
+		SendError: v.readRetryPolicy(name, "send_error"),
+		Reverted:  v.readRetryPolicy(name, "reverted"),
+		NotFound:  v.readRetryPolicy(name, "not_found"),
+	}
+}
+
+func (v *viperConfig) readRetryPolicy(name, kind string) models.RetryPolicy {
+	policy := models.RetryPolicy{
+		Timeout:     defaultRetryTimeout,
+		MaxAttempts: defaultRetryAttempts,
+		Backoff:     defaultRetryBackoff,
+	}
+
+	for _, key := range []string{fmt.Sprintf("workers.%s.retry", name), fmt.Sprintf("workers.%s.retry.%s", name, kind)} {
+		if timeout := v.GetInt64(key + ".timeout"); timeout > 0 {
+			policy.Timeout = timeout
+		}
+		if attempts := v.GetInt64(key + ".max_attempts"); attempts > 0 {
+			policy.MaxAttempts = int(attempts)
+		}
+		if backoff := v.GetFloat64(key + ".backoff"); backoff >= 1 {
+			policy.Backoff = backoff
+		}
+	}
+	return policy
+}
+
+// ReadAlertsConfig reads hooks of alerts from config.json
+func (v *viperConfig) ReadAlertsConfig() *models.AlertsConfig {
+	return &models.AlertsConfig{
+		WebhookURL: v.GetString("alerts.webhook_url"),
+	}
+}
+
+// ReadSLAConfig reads thresholds of stuck swaps monitor from config.json
+func (v *viperConfig) ReadSLAConfig() *models.SLAConfig {
+	routes := make(map[string]int64)
+	for route := range v.GetStringMap("sla.routes") {
+		routes[strings.ToUpper(route)] = v.GetInt64("sla.routes." + route)
+	}
+
+	return &models.SLAConfig{
+		CheckInterval: v.GetInt64("sla.check_interval"),
+		Threshold:     v.GetInt64("sla.threshold"),
+		Routes:        routes,
+	}
+}
+
+// ReadAdminConfig reads admin api token from config.json
+func (v *viperConfig) ReadAdminConfig() *models.AdminConfig {
+	return &models.AdminConfig{
+		Token: v.GetString("admin.token"),
+	}
+}
+
+// ReadMode reads operating mode of relayer from config.json, normal if it is not set
+func (v *viperConfig) ReadMode() string {
+	if mode := v.GetString("mode"); mode != "" {
+		return strings.ToLower(mode)
+	}
+	return models.ModeNormal
+}
+
 // Reads storage params from config.json
 func (v *viperConfig) ReadDBConfig() *models.StorageConfig {
 	return &models.StorageConfig{
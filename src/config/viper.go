@@ -18,6 +18,10 @@ type Config interface {
 	ReadLachainConfig() *models.WorkerConfig
 	ReadFetcherConfig() []*models.FetcherConfig
 	ReadDBConfig() *models.StorageConfig
+	ReadAlertsConfig() *models.AlertsConfig
+	ReadSLAConfig() *models.SLAConfig
+	ReadAdminConfig() *models.AdminConfig
+	ReadMode() string
 	ReadResourceIDs() []*storage.ResourceId
 	ReadChains() []string
 	GetString(key string) string
@@ -24,6 +24,10 @@ func main() {
 	dbConfig := cfg.ReadDBConfig()
 	dbURL := fmt.Sprintf(dbConfig.URL, dbConfig.DBHOST, dbConfig.DBPORT, dbConfig.DBUser, dbConfig.DBName, dbConfig.DBPassword, dbConfig.DBSSL)
 	resourceIDs := cfg.ReadResourceIDs()
+	alertsCfg := cfg.ReadAlertsConfig()
+	slaCfg := cfg.ReadSLAConfig()
+	adminCfg := cfg.ReadAdminConfig()
+	mode := cfg.ReadMode()
 	// init logrus logger
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.TextFormatter{
@@ -48,7 +52,7 @@ func main() {
 	// Set connection to onlife_business database
 	db, err := gorm.Open(dbConfig.DBDriver, dbURL)
 	if err != nil {
-		logger.WithFields(logrus.Fields{"dbURL": dbURL}).Fatalf("Set connection to PostgreSQL: ", err.Error())
+		logger.WithFields(logrus.Fields{"dbURL": dbURL}).Fatalf("Set connection to PostgreSQL: %s", err.Error())
 	}
 	defer db.Close()
 
@@ -63,7 +67,7 @@ func main() {
 		cancel()
 	}()
 
-	app := app.NewApp(logger, srvURL, db, laCfg, chainCfgs, chainFetCfgs, resourceIDs)
+	app := app.NewApp(logger, srvURL, db, dbURL, laCfg, chainCfgs, chainFetCfgs, resourceIDs, alertsCfg, slaCfg, adminCfg, mode)
 
 	//run App
 	app.Run(ctx)
@@ -15,19 +15,101 @@ type ServiceConfig struct {
 	Port string // Service port
 }
 
+// Operating modes of relayer
+const (
+	// ModeNormal watches chains and sends txs
+	ModeNormal = "normal"
+	// ModeObserve watches chains and confirms swaps, never signs or sends txs
+	ModeObserve = "observe"
+	// ModeDrain doesn't send proposals of new swaps, finishes swaps already sent
+	ModeDrain = "drain"
+)
+
 // RelayerStatus ...
 type RelayerStatus struct {
-	Mode    string                  `json:"mode"`
-	Workers map[string]WorkerStatus `json:"workers"`
+	Mode    string                   `json:"mode"`
+	Workers map[string]*WorkerStatus `json:"workers"`
 }
 
 // WorkerStatus ...
 type WorkerStatus struct {
-	Height             int64         `json:"height"`
-	SyncHeight         int64         `json:"sync_height"`
-	LastBlockFetchedAt time.Time     `json:"last_block_fetched_at"`
-	Status             interface{}   `json:"status"`
-	Account            WorkerAccount `json:"account"`
+	Height             int64            `json:"height"`
+	SyncHeight         int64            `json:"sync_height"`
+	LastBlockFetchedAt time.Time        `json:"last_block_fetched_at"`
+	Status             interface{}      `json:"status"`
+	Account            WorkerAccount    `json:"account"`
+	Nonce              NonceStatus      `json:"nonce"`
+	Retry              RetryConfig      `json:"retry"`
+	Providers          []ProviderStatus `json:"providers"`
+	Scan               ScanStatus       `json:"scan"`
+	Headers            HeadersStatus    `json:"headers"`
+	Paused             bool             `json:"paused"`
+	Error              string           `json:"error,omitempty"`
+}
+
+// Readiness is result of checks of service dependencies
+type Readiness struct {
+	Ready      bool              `json:"ready"`
+	Components []ComponentStatus `json:"components"`
+}
+
+// ComponentStatus is result of a dependency check, Chain is empty for database
+type ComponentStatus struct {
+	Component string `json:"component"`
+	Chain     string `json:"chain,omitempty"`
+	Healthy   bool   `json:"healthy"`
+	Value     int64  `json:"value,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// ScanStatus ...
+type ScanStatus struct {
+	// Window is number of blocks requested by one eth_getLogs
+	Window int64 `json:"window"`
+	Behind int64 `json:"behind"`
+	// Rate is blocks scanned per second during catch-up
+	Rate float64 `json:"rate"`
+	// ETA is seconds left till catch-up
+	ETA int64 `json:"eta"`
+}
+
+// HeadersStatus is window of block headers stored to detect reorgs
+type HeadersStatus struct {
+	// History is configured number of kept headers
+	History int64        `json:"block_history"`
+	Stored  int          `json:"stored"`
+	Oldest  *BlockHeader `json:"oldest"`
+	Newest  *BlockHeader `json:"newest"`
+}
+
+// BlockHeader ...
+type BlockHeader struct {
+	Height int64  `json:"height"`
+	Hash   string `json:"hash"`
+}
+
+// ProviderStatus ...
+type ProviderStatus struct {
+	URL     string `json:"url"`
+	Height  int64  `json:"height"`
+	Latency int64  `json:"latency_ms"`
+	Healthy bool   `json:"healthy"`
+	// Active is true for provider which receives calls now
+	Active    bool      `json:"active"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// NonceStatus ...
+type NonceStatus struct {
+	Next         uint64 `json:"next"`
+	LastUsed     uint64 `json:"last_used"`
+	ChainPending uint64 `json:"chain_pending"`
+	ChainLatest  uint64 `json:"chain_latest"`
+	// Gap is number of nonces handed out locally but unknown to the chain
+	Gap int64 `json:"gap"`
+	// Released are nonces given back without broadcast, they are used by the next sends
+	Released []uint64 `json:"released"`
 }
 
 // WorkerAccount ...
@@ -42,6 +124,7 @@ type BlockAndTxLogs struct {
 	ParentBlockHash string
 	BlockTime       int64
 	TxLogs          []*storage.TxLog
+	QuarantinedLogs []*storage.QuarantinedLog
 }
 
 // SwapRequest ...
@@ -72,7 +155,15 @@ type WorkerConfig struct {
 	NetworkType           string         `json:"type"`
 	ChainName             string         `json:"chain_id"`
 	PrivateKey            string         `json:"private_key"`
+	Signer                SignerConfig   `json:"signer"`
 	Provider              string         `json:"provider"`
+	Providers             []string       `json:"providers"`
+	ProviderMaxLag        int64          `json:"provider_max_lag"`
+	ProviderCheckInterval int64          `json:"provider_check_interval"`
+	Subscribe             bool           `json:"subscribe"`
+	MaxBlockRange         int64          `json:"max_block_range"`
+	MaxLag                int64          `json:"max_lag"`
+	GasPriceMaxAge        int64          `json:"gas_price_max_age"`
 	ContractAddr          common.Address `json:"contract_addr"`
 	AmTokenHandlerAddress common.Address `json:"amToken_handler_addr"`
 	AMUSDTContractAddr    common.Address `json:"USDT_token_addr"`
@@ -81,13 +172,49 @@ type WorkerConfig struct {
 	ColdWalletAddr        common.Address `json:"cold_wallet_addr"`
 	FetchInterval         int64          `json:"fetch_interval"`
 	GasLimit              int64          `json:"gas_limit"`
+	GasMargin             int64          `json:"gas_margin"`
 	GasPrice              *big.Int       `json:"gas_price"`
+	TxType                string         `json:"tx_type"`
+	MaxFeePerGas          *big.Int       `json:"max_fee_per_gas"`
+	MaxPriorityFeePerGas  *big.Int       `json:"max_priority_fee_per_gas"`
+	MaxGasPrice           *big.Int       `json:"max_gas_price"`
+	GasPriceBump          int64          `json:"gas_price_bump"`
+	ReplaceTimeout        int64          `json:"replace_timeout"`
+	Retry                 RetryConfig    `json:"retry"`
 	ChainDecimal          int            `json:"chain_decimal"`
 	ConfirmNum            int64          `json:"confirm_num"`
+	BlockHistory          int64          `json:"block_history"`
 	StartBlockHeight      int64          `json:"start_block_height"`
 	DestinationChainID    string         `json:"dest_id"`
 }
 
+// SignerConfig ...
+type SignerConfig struct {
+	Type           string `json:"type"`
+	KeystorePath   string `json:"keystore_path"`
+	PassphraseEnv  string `json:"passphrase_env"`
+	PassphraseFile string `json:"passphrase_file"`
+	URL            string `json:"url"`
+	// env variable with bearer token of remote signer
+	TokenEnv string `json:"token_env"`
+	// timeout of request to remote signer in seconds
+	Timeout int64 `json:"timeout"`
+}
+
+// RetryConfig contains auto-retry policies of the chain per kind of failure
+type RetryConfig struct {
+	SendError RetryPolicy `json:"send_error"`
+	Reverted  RetryPolicy `json:"reverted"`
+	NotFound  RetryPolicy `json:"not_found"`
+}
+
+// RetryPolicy ...
+type RetryPolicy struct {
+	Timeout     int64   `json:"timeout"`
+	MaxAttempts int     `json:"max_attempts"`
+	Backoff     float64 `json:"backoff"`
+}
+
 type TssConfig struct {
 	Address    string
 	BaseFolder string
@@ -98,8 +225,91 @@ type RelayerConfig struct {
 	Address common.Address
 }
 
+// AlertsConfig ...
+type AlertsConfig struct {
+	WebhookURL string `json:"webhook_url"`
+}
+
+// AdminConfig ...
+type AdminConfig struct {
+	// bearer token of admin api, api is disabled if it is empty
+	Token string `json:"token"`
+}
+
+// SLAConfig sets time in seconds for swap to reach terminal status,
+// Routes overrides Threshold for routes named like "ETH-LA"(origin-destination chain)
+type SLAConfig struct {
+	CheckInterval int64            `json:"check_interval"`
+	Threshold     int64            `json:"threshold"`
+	Routes        map[string]int64 `json:"routes"`
+}
+
+// StuckSwap is swap not finished within SLA of its route
+type StuckSwap struct {
+	SwapID      string `json:"swap_id"`
+	Route       string `json:"route"`
+	Status      string `json:"status"`
+	Age         int64  `json:"age"`
+	Threshold   int64  `json:"threshold"`
+	DepositTime int64  `json:"deposit_time"`
+	UpdateTime  int64  `json:"update_time"`
+}
+
 // FetcherConfig
 type FetcherConfig struct {
 	ChainName string
 	URL       string
 }
+
+// ReparseResult is outcome of re-parsing quarantined log, released log is moved into 'tx_logs'
+type ReparseResult struct {
+	ID       int64  `json:"id"`
+	Chain    string `json:"chain"`
+	TxHash   string `json:"tx_hash"`
+	Released bool   `json:"released"`
+	TxType   string `json:"tx_type,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// SwapDetails is swap with every tx seen on chains and every tx sent by relayer for it
+type SwapDetails struct {
+	Swap             *storage.Event    `json:"swap"`
+	OriginChain      string            `json:"origin_chain"`
+	DestinationChain string            `json:"destination_chain"`
+	Token            string            `json:"token"`
+	TxLogs           []*storage.TxLog  `json:"tx_logs"`
+	TxsSent          []*storage.TxSent `json:"txs_sent"`
+}
+
+// SwapsQuery is filter of swaps listing, chains are names like "ETH", From and To are unix seconds
+type SwapsQuery struct {
+	Sender           string
+	Receiver         string
+	Address          string
+	OriginChain      string
+	DestinationChain string
+	ResourceID       string
+	Statuses         []string
+	From             int64
+	To               int64
+	Cursor           string
+	Desc             bool
+	Limit            int
+}
+
+// SwapsPage is page of swaps listing, NextCursor is empty on the last page
+type SwapsPage struct {
+	Swaps      []*storage.Event `json:"swaps"`
+	NextCursor string           `json:"next_cursor,omitempty"`
+}
+
+// SwapUpdate is status change of the swap pushed to stream subscribers
+type SwapUpdate struct {
+	SwapID             string `json:"swap_id"`
+	Status             string `json:"status"`
+	SenderAddr         string `json:"sender_addr"`
+	ReceiverAddr       string `json:"receiver_addr"`
+	OriginChainID      string `json:"origin_chain_id"`
+	DestinationChainID string `json:"destination_chain_id"`
+	UpdateTime         int64  `json:"update_time"`
+}
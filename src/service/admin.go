@@ -0,0 +1,112 @@
+package rlr
+
+import (
+	"fmt"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+	"github.com/latoken/bridge-backend-service/src/service/workers/utils"
+)
+
+// ResendProposal puts swap back to the queue of proposals, proposal is sent again
+// unless it is executed or cancelled on chain, it is refused while any tx of the swap may be mined
+func (r *BridgeSRV) ResendProposal(swapID string, audit *storage.AdminAction) error {
+	return r.storage.SetEventStatusByAdmin(swapID, []storage.EventStatus{
+		storage.EventStatusPassedSent,
+		storage.EventStatusPassedSentFailed,
+		storage.EventStatusPassedFailed,
+	}, storage.EventStatusPassedInitConfrimed, true, audit)
+}
+
+// FailSwap marks pending swap failed, status is updated on lachain then,
+// it is refused while any tx of the swap may be mined
+func (r *BridgeSRV) FailSwap(swapID string, audit *storage.AdminAction) error {
+	if err := r.checkNotExecuted(swapID); err != nil {
+		return err
+	}
+	return r.storage.SetEventStatusByAdmin(swapID, storage.PendingEventStatuses, storage.EventStatusPassedFailed, true, audit)
+}
+
+// RefundSwap marks pending swap refunded, it is not processed anymore,
+// it is refused while any tx of the swap may be mined
+func (r *BridgeSRV) RefundSwap(swapID string, audit *storage.AdminAction) error {
+	if err := r.checkNotExecuted(swapID); err != nil {
+		return err
+	}
+	return r.storage.SetEventStatusByAdmin(swapID, storage.PendingEventStatuses, storage.EventStatusRefunded, true, audit)
+}
+
+// RequeueUpdate puts swap with failed update back to the queue of confirmations to lachain,
+// status to confirm is taken from the proposal on destination chain
+func (r *BridgeSRV) RequeueUpdate(swapID string, audit *storage.AdminAction) error {
+	event, err := r.storage.GetEventBySwapID(swapID)
+	if err != nil {
+		return err
+	}
+	status, err := r.proposalStatus(event)
+	if err != nil {
+		return err
+	}
+
+	newStatus := storage.EventStatusPassedFailed
+	if status == storage.ProposalStatusExecuted {
+		newStatus = storage.EventStatusPassedConfirmed
+	}
+	return r.storage.SetEventStatusByAdmin(swapID, []storage.EventStatus{storage.EventStatusUpdateFailed}, newStatus, false, audit)
+}
+
+// ResetCursor moves watcher of the chain to height
+func (r *BridgeSRV) ResetCursor(chain string, height int64) error {
+	return r.Watcher.ResetCursor(chain, height)
+}
+
+// AuditAdminAction records admin action
+func (r *BridgeSRV) AuditAdminAction(action *storage.AdminAction) {
+	if err := r.storage.CreateAdminAction(action); err != nil {
+		r.logger.Errorf("save admin action error, action=%s, target=%s, err=%v", action.Action, action.Target, err)
+	}
+}
+
+// GetAdminActions returns the last admin actions
+func (r *BridgeSRV) GetAdminActions(limit int) ([]*storage.AdminAction, error) {
+	return r.storage.GetAdminActions(limit)
+}
+
+// checkNotExecuted returns error if proposal of the swap is executed on destination chain,
+// such swap can't be failed or refunded without double spend
+func (r *BridgeSRV) checkNotExecuted(swapID string) error {
+	event, err := r.storage.GetEventBySwapID(swapID)
+	if err != nil {
+		return err
+	}
+	status, err := r.proposalStatus(event)
+	if err != nil {
+		return err
+	}
+	if status == storage.ProposalStatusExecuted {
+		return fmt.Errorf("proposal %s is executed on chain", swapID)
+	}
+	return nil
+}
+
+func (r *BridgeSRV) proposalStatus(event *storage.Event) (storage.ProposalStatus, error) {
+	worker := r.workerByDestinationID(event.DestinationChainID)
+	if worker == nil {
+		return 0, fmt.Errorf("unknown destination chain %s", event.DestinationChainID)
+	}
+	status, err := worker.GetProposalStatus(event.DepositNonce, utils.StringToBytes8(event.OriginChainID), utils.StringToBytes8(event.DestinationChainID),
+		utils.StringToBytes32(event.ResourceID), event.ReceiverAddr, event.OutAmount)
+	if err != nil {
+		return 0, fmt.Errorf("could not get proposal status: %w", err)
+	}
+	return status, nil
+}
+
+func (r *BridgeSRV) workerByDestinationID(chainID string) workers.IWorker {
+	for _, worker := range r.Workers {
+		if worker.GetDestinationID() == chainID {
+			return worker
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package alerts
+
+import (
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Level ...
+type Level string
+
+const (
+	LevelWarning  Level = "WARNING"
+	LevelCritical Level = "CRITICAL"
+)
+
+// Alert is a problem which needs attention of operators
+type Alert struct {
+	Level   Level  `json:"level"`
+	Chain   string `json:"chain"`
+	Title   string `json:"title"`
+	Message string `json:"message"`
+	Time    int64  `json:"time"`
+}
+
+// Hook delivers alerts outside of the service
+type Hook interface {
+	Fire(alert *Alert) error
+}
+
+// Alerter writes alerts into log and fires them to configured hooks
+type Alerter struct {
+	logger *logrus.Entry
+	hooks  []Hook
+}
+
+// NewAlerter ...
+func NewAlerter(logger *logrus.Logger, cfg *models.AlertsConfig) *Alerter {
+	alerter := &Alerter{
+		logger: logger.WithField("layer", "alerts"),
+	}
+	if cfg != nil && cfg.WebhookURL != "" {
+		alerter.AddHook(newWebhookHook(cfg.WebhookURL))
+	}
+	return alerter
+}
+
+// AddHook ...
+func (a *Alerter) AddHook(hook Hook) {
+	a.hooks = append(a.hooks, hook)
+}
+
+// Raise logs alert and fires it to hooks in background
+func (a *Alerter) Raise(level Level, chain, title, message string) {
+	alert := &Alert{
+		Level:   level,
+		Chain:   chain,
+		Title:   title,
+		Message: message,
+		Time:    time.Now().Unix(),
+	}
+
+	entry := a.logger.WithFields(logrus.Fields{"chain": chain, "level": level})
+	if level == LevelCritical {
+		entry.Errorf("ALERT %s: %s", title, message)
+	} else {
+		entry.Warnf("ALERT %s: %s", title, message)
+	}
+
+	for _, hook := range a.hooks {
+		go func(hook Hook) {
+			if err := hook.Fire(alert); err != nil {
+				a.logger.Errorf("fire alert %q error, err = %v", title, err)
+			}
+		}(hook)
+	}
+}
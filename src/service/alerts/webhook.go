@@ -0,0 +1,49 @@
+package alerts
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const webhookTimeout = 10 * time.Second
+
+// webhookHook posts alert as json, 'text' field makes it readable by chat webhooks like Slack
+type webhookHook struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhookHook(url string) *webhookHook {
+	return &webhookHook{
+		url:    url,
+		client: &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// Fire ...
+func (h *webhookHook) Fire(alert *Alert) error {
+	body, err := json.Marshal(struct {
+		Text string `json:"text"`
+		*Alert
+	}{
+		Text:  fmt.Sprintf("[%s] %s %s: %s", alert.Level, alert.Chain, alert.Title, alert.Message),
+		Alert: alert,
+	})
+	if err != nil {
+		return err
+	}
+
+	resp, err := h.client.Post(h.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("webhook responded with status %s", resp.Status)
+	}
+	return nil
+}
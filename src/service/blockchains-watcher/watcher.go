@@ -1,29 +1,54 @@
 package watcher
 
 import (
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/latoken/bridge-backend-service/src/models"
 	"github.com/latoken/bridge-backend-service/src/service/storage"
 	"github.com/latoken/bridge-backend-service/src/service/workers"
 
 	"github.com/sirupsen/logrus"
 )
 
+// interval between attempts to switch from polling to subscription
+const resubscribeInterval = 30 * time.Second
+
+// blockStore keeps scanned blocks and txs of the chains, it is implemented by storage.DataBase
+type blockStore interface {
+	GetCurrentBlockLog(chain string) storage.BlockLog
+	GetBlockLogs(chain string) []*storage.BlockLog
+	SaveBlockAndTxs(chain string, blockLog *storage.BlockLog, txLogs []*storage.TxLog, quarantinedLogs []*storage.QuarantinedLog, history int64) error
+	UpdateConfirmedNum(chain string, height int64) error
+	DeleteBlockAndTxs(chain string, height int64) error
+	RewindBlockLog(chain string, height int64) (bool, error)
+	ReanchorBlockLog(chain string, height int64, blockHash string) error
+	ResetBlockLog(blockLog *storage.BlockLog) error
+}
+
 // WatcherSRV ...
 type WatcherSRV struct {
 	logger  *logrus.Entry
-	storage *storage.DataBase
+	storage blockStore
 	Workers map[string]workers.IWorker
+	// cursor of the chain is moved under its lock, so manual reset doesn't interleave with scanning
+	locks map[string]*sync.Mutex
 }
 
 // CreateNewWatcherSRV ...
 func CreateNewWatcherSRV(logger *logrus.Logger, db *storage.DataBase, workers map[string]workers.IWorker) *WatcherSRV {
+	locks := make(map[string]*sync.Mutex, len(workers))
+	for name := range workers {
+		locks[name] = &sync.Mutex{}
+	}
 	return &WatcherSRV{
 		logger:  logger.WithField("layer", "watcher"),
 		storage: db,
 		Workers: workers,
+		locks:   locks,
 	}
 }
 
@@ -43,7 +68,10 @@ func (w *WatcherSRV) Run() {
 }
 
 func (w *WatcherSRV) collector(worker workers.IWorker, threshold time.Duration, startHeight int64) {
+	var subscribedAt time.Time
+	lock := w.locks[worker.GetChainName()]
 	for {
+		lock.Lock()
 		curBlockLog := w.storage.GetCurrentBlockLog(worker.GetChainName())
 		if curBlockLog.Height == 0 {
 			w.logger.Warnf("%s current height: %d", worker.GetChainName(), curBlockLog.Height)
@@ -56,11 +84,19 @@ func (w *WatcherSRV) collector(worker workers.IWorker, threshold time.Duration,
 			height = startHeight
 		}
 
-		if err := w.getBlock(worker, height, curBlockLog.BlockHash); err != nil {
+		err := w.getBlock(worker, height, curBlockLog.BlockHash)
+		lock.Unlock()
+		if err != nil {
 			normalizedErr := strings.ToLower(err.Error())
 			if strings.Contains(normalizedErr, "height must be less than or equal to the current blockchain height") ||
 				strings.Contains(normalizedErr, "not found") ||
 				strings.Contains(normalizedErr, "block number out of range") {
+				// polling has caught up with the chain, switch to subscription if it is enabled
+				if worker.GetConfig().Subscribe && time.Since(subscribedAt) > resubscribeInterval {
+					subscribedAt = time.Now()
+					w.subscribe(worker)
+					continue
+				}
 				w.logger.Infof("try to get ahead block, chain=%s, height=%d", worker.GetChainName(), height)
 			} else {
 				w.logger.Error(normalizedErr)
@@ -68,17 +104,144 @@ func (w *WatcherSRV) collector(worker workers.IWorker, threshold time.Duration,
 			time.Sleep(threshold)
 		}
 
-		// TODO
-		time.Sleep(time.Second)
+		// scan without pause while catching up with the chain
+		if worker.GetScanStatus().Behind == 0 {
+			time.Sleep(time.Second)
+		}
 	}
 }
 
 func (w *WatcherSRV) getBlock(worker workers.IWorker, curHeight int64, curBlockHash string) error {
+	// check that the stored cursor is still on the canonical chain
+	if curBlockHash != "" {
+		blockHash, err := worker.GetBlockHash(curHeight)
+		if err != nil {
+			return fmt.Errorf("get %s block hash error, height =%d, err=%s", worker.GetChainName(), curHeight, err.Error())
+		}
+		if blockHash != curBlockHash {
+			w.logger.Warnf("reorg detected, chain=%s, height=%d, stored hash=%s, chain hash=%s",
+				worker.GetChainName(), curHeight, curBlockHash, blockHash)
+			return w.rollback(worker)
+		}
+	}
+
 	blockAndTxLogs, err := worker.GetBlockAndTxs(curHeight)
 	if err != nil {
 		return fmt.Errorf("get %s block info error, height reached =%d, err=%s", worker.GetChainName(), curHeight, err.Error())
 	}
 
+	return w.saveBlock(worker, blockAndTxLogs)
+}
+
+// subscribe saves blocks sent by worker subscription until it drops or
+// the block does not follow the stored cursor, collector backfills the gap by polling then
+func (w *WatcherSRV) subscribe(worker workers.IWorker) {
+	chain := worker.GetChainName()
+	blocks := make(chan *models.BlockAndTxLogs)
+	sub, err := worker.SubscribeBlockAndTxs(blocks)
+	if err != nil {
+		w.logger.Warnf("subscribe error, chain=%s, keep polling, err=%v", chain, err)
+		return
+	}
+	defer sub.Unsubscribe()
+	w.logger.Infof("subscribed to new blocks, chain=%s", chain)
+
+	for {
+		select {
+		case err := <-sub.Err():
+			var rescanErr *workers.RescanError
+			if errors.As(err, &rescanErr) {
+				if err := w.rewind(worker, rescanErr.Height-1); err != nil {
+					w.logger.Errorf("rewind cursor error, chain=%s, height=%d, err=%v", chain, rescanErr.Height-1, err)
+				}
+			}
+			w.logger.Warnf("subscription dropped, chain=%s, fall back to polling, err=%v", chain, err)
+			return
+		case blockAndTxLogs := <-blocks:
+			if !w.saveSubscribedBlock(worker, blockAndTxLogs) {
+				return
+			}
+		}
+	}
+}
+
+// saveSubscribedBlock saves block if it follows the stored cursor, returns false if subscription must be dropped
+func (w *WatcherSRV) saveSubscribedBlock(worker workers.IWorker, blockAndTxLogs *models.BlockAndTxLogs) bool {
+	chain := worker.GetChainName()
+	lock := w.locks[chain]
+	lock.Lock()
+	defer lock.Unlock()
+
+	curBlockLog := w.storage.GetCurrentBlockLog(chain)
+	if blockAndTxLogs.Height != curBlockLog.Height+1 || blockAndTxLogs.ParentBlockHash != curBlockLog.BlockHash {
+		w.logger.Warnf("subscription block does not follow cursor, chain=%s, cursor=%d, block=%d, fall back to polling",
+			chain, curBlockLog.Height, blockAndTxLogs.Height)
+		return false
+	}
+	w.logger.Infof("%s new block from subscription: %d", chain, blockAndTxLogs.Height)
+	if err := w.saveBlock(worker, blockAndTxLogs); err != nil {
+		w.logger.Errorf("save block error, chain=%s, height=%d, err=%v", chain, blockAndTxLogs.Height, err)
+		return false
+	}
+	return true
+}
+
+// ResetCursor moves cursor of the chain to height, watcher scans blocks after it then
+func (w *WatcherSRV) ResetCursor(chain string, height int64) error {
+	worker, ok := w.Workers[chain]
+	if !ok {
+		return fmt.Errorf("unknown chain %s", chain)
+	}
+	if height <= 0 {
+		return fmt.Errorf("height must be positive")
+	}
+
+	lock := w.locks[chain]
+	lock.Lock()
+	defer lock.Unlock()
+
+	return w.resetCursor(worker, height)
+}
+
+// rewind moves cursor of the chain back to height, so blocks after it are scanned again.
+// Cursor is moved to the highest stored block at or below height, if there is none it is reset to height
+func (w *WatcherSRV) rewind(worker workers.IWorker, height int64) error {
+	chain := worker.GetChainName()
+	lock := w.locks[chain]
+	lock.Lock()
+	defer lock.Unlock()
+
+	found, err := w.storage.RewindBlockLog(chain, height)
+	if err != nil || found {
+		return err
+	}
+	return w.resetCursor(worker, height)
+}
+
+// resetCursor must be called under lock of the chain
+func (w *WatcherSRV) resetCursor(worker workers.IWorker, height int64) error {
+	chain := worker.GetChainName()
+	blockHash, err := worker.GetBlockHash(height)
+	if err != nil {
+		return fmt.Errorf("get %s block hash error, height =%d, err=%s", chain, height, err.Error())
+	}
+	parentHash, err := worker.GetBlockHash(height - 1)
+	if err != nil {
+		return fmt.Errorf("get %s block hash error, height =%d, err=%s", chain, height-1, err.Error())
+	}
+
+	w.logger.Warnf("reset cursor, chain=%s, height=%d, hash=%s", chain, height, blockHash)
+	return w.storage.ResetBlockLog(&storage.BlockLog{
+		Chain:      chain,
+		BlockHash:  blockHash,
+		ParentHash: parentHash,
+		Height:     height,
+		CreateTime: time.Now().Unix(),
+	})
+}
+
+// saveBlock puts block header and txs into database as the new cursor of the chain
+func (w *WatcherSRV) saveBlock(worker workers.IWorker, blockAndTxLogs *models.BlockAndTxLogs) error {
 	parentHash := blockAndTxLogs.ParentBlockHash
 
 	nextBlockLog := storage.BlockLog{
@@ -92,7 +255,7 @@ func (w *WatcherSRV) getBlock(worker workers.IWorker, curHeight int64, curBlockH
 	}
 
 	// put block header and block txs into database
-	if err := w.storage.SaveBlockAndTxs(worker.GetChainName(), &nextBlockLog, blockAndTxLogs.TxLogs); err != nil {
+	if err := w.storage.SaveBlockAndTxs(worker.GetChainName(), &nextBlockLog, blockAndTxLogs.TxLogs, blockAndTxLogs.QuarantinedLogs, worker.GetBlockHistory()); err != nil {
 		return err
 	}
 
@@ -103,3 +266,31 @@ func (w *WatcherSRV) getBlock(worker workers.IWorker, curHeight int64, curBlockH
 
 	return nil
 }
+
+// rollback walks back stored block headers to the common ancestor with the canonical chain,
+// deletes orphaned blocks with their not yet confirmed txs, so collector re-scans from the ancestor
+func (w *WatcherSRV) rollback(worker workers.IWorker) error {
+	chain := worker.GetChainName()
+	blockLogs := w.storage.GetBlockLogs(chain)
+	if len(blockLogs) == 0 {
+		return nil
+	}
+
+	var blockHash string
+	for _, blockLog := range blockLogs {
+		hash, err := worker.GetBlockHash(blockLog.Height)
+		if err != nil {
+			return fmt.Errorf("get %s block hash error, height =%d, err=%s", chain, blockLog.Height, err.Error())
+		}
+		if hash == blockLog.BlockHash {
+			w.logger.Warnf("rollback to common ancestor, chain=%s, height=%d, hash=%s", chain, blockLog.Height, hash)
+			return w.storage.DeleteBlockAndTxs(chain, blockLog.Height)
+		}
+		blockHash = hash
+	}
+
+	// reorg is deeper than stored headers, re-scan from the oldest one
+	oldest := blockLogs[len(blockLogs)-1]
+	w.logger.Errorf("common ancestor not found in stored headers, chain=%s, re-scan from height=%d", chain, oldest.Height)
+	return w.storage.ReanchorBlockLog(chain, oldest.Height, blockHash)
+}
@@ -0,0 +1,233 @@
+package watcher
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	"github.com/latoken/bridge-backend-service/src/service/workers"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeWorker serves blocks of the chain by hashes, the rest of IWorker is not used by watcher tests
+type fakeWorker struct {
+	workers.IWorker
+	hashes map[int64]string
+}
+
+func (w *fakeWorker) GetChainName() string   { return "ETH" }
+func (w *fakeWorker) GetBlockHistory() int64 { return 10 }
+
+func (w *fakeWorker) GetBlockHash(height int64) (string, error) {
+	hash, ok := w.hashes[height]
+	if !ok {
+		return "", fmt.Errorf("block %d not found", height)
+	}
+	return hash, nil
+}
+
+func (w *fakeWorker) GetBlockAndTxs(height int64) (*models.BlockAndTxLogs, error) {
+	hash, err := w.GetBlockHash(height + 1)
+	if err != nil {
+		return nil, err
+	}
+	return &models.BlockAndTxLogs{
+		Height:          height + 1,
+		BlockHash:       hash,
+		ParentBlockHash: w.hashes[height],
+		TxLogs:          []*storage.TxLog{{Chain: "ETH", Height: height + 1}},
+	}, nil
+}
+
+// fakeBlockStore keeps blocks and not yet confirmed txs in memory
+type fakeBlockStore struct {
+	blocks map[int64]*storage.BlockLog
+	txLogs []*storage.TxLog
+	resets int
+}
+
+func newFakeBlockStore(hashes map[int64]string, from, to int64) *fakeBlockStore {
+	s := &fakeBlockStore{blocks: make(map[int64]*storage.BlockLog)}
+	for height := from; height <= to; height++ {
+		s.blocks[height] = &storage.BlockLog{Chain: "ETH", Height: height, BlockHash: hashes[height], ParentHash: hashes[height-1]}
+		s.txLogs = append(s.txLogs, &storage.TxLog{Chain: "ETH", Height: height})
+	}
+	return s
+}
+
+func (s *fakeBlockStore) GetCurrentBlockLog(chain string) storage.BlockLog {
+	if logs := s.GetBlockLogs(chain); len(logs) > 0 {
+		return *logs[0]
+	}
+	return storage.BlockLog{}
+}
+
+func (s *fakeBlockStore) GetBlockLogs(chain string) []*storage.BlockLog {
+	logs := make([]*storage.BlockLog, 0, len(s.blocks))
+	for _, blockLog := range s.blocks {
+		logs = append(logs, blockLog)
+	}
+	sort.Slice(logs, func(i, j int) bool { return logs[i].Height > logs[j].Height })
+	return logs
+}
+
+func (s *fakeBlockStore) SaveBlockAndTxs(chain string, blockLog *storage.BlockLog, txLogs []*storage.TxLog, quarantinedLogs []*storage.QuarantinedLog, history int64) error {
+	s.blocks[blockLog.Height] = blockLog
+	s.txLogs = append(s.txLogs, txLogs...)
+	return nil
+}
+
+func (s *fakeBlockStore) UpdateConfirmedNum(chain string, height int64) error {
+	return nil
+}
+
+func (s *fakeBlockStore) DeleteBlockAndTxs(chain string, height int64) error {
+	for h := range s.blocks {
+		if h > height {
+			delete(s.blocks, h)
+		}
+	}
+	txLogs := s.txLogs[:0]
+	for _, txLog := range s.txLogs {
+		if txLog.Height <= height {
+			txLogs = append(txLogs, txLog)
+		}
+	}
+	s.txLogs = txLogs
+	return nil
+}
+
+func (s *fakeBlockStore) RewindBlockLog(chain string, height int64) (bool, error) {
+	for _, blockLog := range s.GetBlockLogs(chain) {
+		if blockLog.Height <= height {
+			return true, s.DeleteBlockAndTxs(chain, blockLog.Height)
+		}
+	}
+	return false, nil
+}
+
+func (s *fakeBlockStore) ReanchorBlockLog(chain string, height int64, blockHash string) error {
+	if err := s.DeleteBlockAndTxs(chain, height); err != nil {
+		return err
+	}
+	s.blocks[height].BlockHash = blockHash
+	return nil
+}
+
+func (s *fakeBlockStore) ResetBlockLog(blockLog *storage.BlockLog) error {
+	s.resets++
+	if err := s.DeleteBlockAndTxs(blockLog.Chain, blockLog.Height); err != nil {
+		return err
+	}
+	s.blocks = map[int64]*storage.BlockLog{blockLog.Height: blockLog}
+	return nil
+}
+
+func (s *fakeBlockStore) maxTxHeight() int64 {
+	var max int64
+	for _, txLog := range s.txLogs {
+		if txLog.Height > max {
+			max = txLog.Height
+		}
+	}
+	return max
+}
+
+// chainHashes returns hashes of blocks from..to, blocks above fork have prefix of the fork
+func chainHashes(from, to, fork int64, prefix string) map[int64]string {
+	hashes := make(map[int64]string)
+	for height := from; height <= to; height++ {
+		hashes[height] = fmt.Sprintf("0x%d", height)
+		if height > fork {
+			hashes[height] = fmt.Sprintf("0x%s%d", prefix, height)
+		}
+	}
+	return hashes
+}
+
+func newTestWatcher(store *fakeBlockStore, worker *fakeWorker) *WatcherSRV {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return &WatcherSRV{
+		logger:  logger.WithField("layer", "watcher"),
+		storage: store,
+		Workers: map[string]workers.IWorker{"ETH": worker},
+		locks:   map[string]*sync.Mutex{"ETH": {}},
+	}
+}
+
+func TestGetBlockRollback(t *testing.T) {
+	stored := chainHashes(90, 110, 110, "")
+	tests := []struct {
+		name       string
+		chain      map[int64]string
+		wantHeight int64
+		wantHash   string
+	}{
+		{"cursor on canonical chain", chainHashes(90, 110, 110, ""), 106, "0x106"},
+		{"reorg of the last block", chainHashes(90, 110, 104, "b"), 104, "0x104"},
+		{"reorg of three blocks", chainHashes(90, 110, 102, "b"), 102, "0x102"},
+		{"reorg deeper than stored headers", chainHashes(90, 110, 95, "b"), 100, "0xb100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeBlockStore(stored, 100, 105)
+			w := newTestWatcher(store, &fakeWorker{hashes: tt.chain})
+
+			cursor := store.GetCurrentBlockLog("ETH")
+			if err := w.getBlock(w.Workers["ETH"], cursor.Height, cursor.BlockHash); err != nil {
+				t.Fatal(err)
+			}
+
+			cursor = store.GetCurrentBlockLog("ETH")
+			if cursor.Height != tt.wantHeight || cursor.BlockHash != tt.wantHash {
+				t.Fatalf("cursor %d %s, want %d %s", cursor.Height, cursor.BlockHash, tt.wantHeight, tt.wantHash)
+			}
+			if max := store.maxTxHeight(); max != tt.wantHeight {
+				t.Fatalf("txs are kept up to %d, want %d", max, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func TestRewind(t *testing.T) {
+	hashes := chainHashes(90, 110, 110, "")
+	tests := []struct {
+		name       string
+		height     int64
+		wantHeight int64
+		wantResets int
+	}{
+		{"stored block", 102, 102, 0},
+		{"cursor is below height", 108, 105, 0},
+		{"height is below stored blocks", 97, 97, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := newFakeBlockStore(hashes, 100, 105)
+			w := newTestWatcher(store, &fakeWorker{hashes: hashes})
+
+			if err := w.rewind(w.Workers["ETH"], tt.height); err != nil {
+				t.Fatal(err)
+			}
+
+			cursor := store.GetCurrentBlockLog("ETH")
+			if cursor.Height != tt.wantHeight || cursor.BlockHash != hashes[tt.wantHeight] {
+				t.Fatalf("cursor %d %s, want %d %s", cursor.Height, cursor.BlockHash, tt.wantHeight, hashes[tt.wantHeight])
+			}
+			if max := store.maxTxHeight(); max > tt.wantHeight {
+				t.Fatalf("txs above cursor are kept up to %d", max)
+			}
+			if store.resets != tt.wantResets {
+				t.Fatalf("cursor is reset %d times, want %d", store.resets, tt.wantResets)
+			}
+		})
+	}
+}
@@ -1,11 +1,14 @@
 package rlr
 
 import (
+	"fmt"
 	"sync"
 	"time"
 
+	"github.com/latoken/bridge-backend-service/src/service/alerts"
 	watcher "github.com/latoken/bridge-backend-service/src/service/blockchains-watcher"
 	fetcher "github.com/latoken/bridge-backend-service/src/service/gas-price-fetcher"
+	"github.com/latoken/bridge-backend-service/src/service/metrics"
 	"github.com/latoken/bridge-backend-service/src/service/storage"
 	workers "github.com/latoken/bridge-backend-service/src/service/workers"
 	"github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible"
@@ -16,32 +19,69 @@ import (
 	"github.com/latoken/bridge-backend-service/src/models"
 )
 
+const (
+	defaultReplaceTimeout = 600
+	// interval of retries to link txs, which have arrived before their swap, and number of txs linked at once,
+	// txs older than max age are left unlinked, they match several swaps
+	linkTxLogsInterval = time.Minute
+	linkTxLogsBatch    = 100
+	linkTxLogsMaxAge   = 24 * time.Hour
+)
+
+// txLinkStore keeps txs and swaps they are linked to, it is implemented by storage.DataBase
+type txLinkStore interface {
+	GetEventsByDeposit(originChainID string, depositNonce uint64, resourceID string) ([]*storage.Event, error)
+	GetUnlinkedTxLogs(chain string, txType storage.TxType, createdAfter int64, limit int) ([]*storage.TxLog, error)
+	UpdateTxLogSwap(txLog *storage.TxLog) error
+}
+
 // BridgeSRV ...
 type BridgeSRV struct {
 	sync.RWMutex
 	logger   *logrus.Logger
 	Watcher  *watcher.WatcherSRV
 	Fetcher  *fetcher.FetcherSrv
+	Stream   *SwapStream
 	laWorker workers.IWorker
 	Workers  map[string]workers.IWorker
 	storage  *storage.DataBase
+	alerter  *alerts.Alerter
+	// paused bridge contracts by chain, proposals are not sent to them
+	paused    map[string]bool
+	slaConfig *models.SLAConfig
+	// swaps found stuck by the last scan by swap id
+	stuckSwaps map[string]*models.StuckSwap
+	// sent txs failed replacement and alerted by hash
+	stuckTxs map[string]bool
+	// the last known operating mode, one of models.Mode*, it is kept in database
+	mode string
 }
 
 // CreateNewBridgeSRV ...
-func CreateNewBridgeSRV(logger *logrus.Logger, gormDB *gorm.DB, laConfig *models.WorkerConfig, chainCfgs []*models.WorkerConfig,
-	chainFetCfgs []*models.FetcherConfig, resourceIDs []*storage.ResourceId) *BridgeSRV {
+func CreateNewBridgeSRV(logger *logrus.Logger, gormDB *gorm.DB, dbURL string, laConfig *models.WorkerConfig, chainCfgs []*models.WorkerConfig,
+	chainFetCfgs []*models.FetcherConfig, resourceIDs []*storage.ResourceId, alertsCfg *models.AlertsConfig, slaCfg *models.SLAConfig, mode string) *BridgeSRV {
 	// init database
 	db, err := storage.InitStorage(gormDB)
 	if err != nil {
-		logger.Fatalf("Connect to DataBase: ", err)
+		logger.Fatalf("Connect to DataBase: %v", err)
 	}
+	// resource ids are used by workers to find handler contracts
+	db.SaveResourceIDs(resourceIDs)
 
 	// create Relayer instance
 	inst := BridgeSRV{
-		logger:   logger,
-		storage:  db,
-		laWorker: eth.NewErc20Worker(logger, laConfig, db),
-		Workers:  make(map[string]workers.IWorker),
+		logger:     logger,
+		storage:    db,
+		alerter:    alerts.NewAlerter(logger, alertsCfg),
+		paused:     make(map[string]bool),
+		slaConfig:  slaCfg,
+		stuckSwaps: make(map[string]*models.StuckSwap),
+		stuckTxs:   make(map[string]bool),
+		laWorker:   eth.NewErc20Worker(logger, laConfig, db),
+		Workers:    make(map[string]workers.IWorker),
+	}
+	if err := inst.initMode(mode); err != nil {
+		logger.Fatalf("Set mode: %v", err)
 	}
 	// create erc20 worker
 	for _, cfg := range chainCfgs {
@@ -57,8 +97,8 @@ func CreateNewBridgeSRV(logger *logrus.Logger, gormDB *gorm.DB, laConfig *models
 	}
 	inst.Watcher = watcher.CreateNewWatcherSRV(logger, db, inst.Workers)
 	inst.Fetcher = fetcher.CreateFetcherSrv(logger, db, chainFetCfgs)
+	inst.Stream = NewSwapStream(logger, dbURL)
 
-	db.SaveResourceIDs(resourceIDs)
 	return &inst
 }
 
@@ -70,18 +110,33 @@ func (r *BridgeSRV) Run() {
 	r.Watcher.Run()
 	//start fetcher
 	r.Fetcher.Run()
+	//start swaps stream
+	r.Stream.Run()
 	go r.UpdateTxOnLachain()
+	go r.StuckSwapsMonitor()
+	go r.MetricsCollector()
+	// proposals are not sent until pause state of bridge contract is known
+	for _, worker := range r.Workers {
+		r.checkContractState(worker)
+	}
 	// run Worker workers
 	for _, worker := range r.Workers {
 		go r.ConfirmWorkerTx(worker)
 		go r.emitProposal(worker)
 		go r.CheckTxSentRoutine(worker)
+		go r.SecurityMonitor(worker)
 	}
 }
 
 // ConfirmWorkerTx ...
 func (r *BridgeSRV) ConfirmWorkerTx(worker workers.IWorker) {
+	var linkedAt time.Time
 	for {
+		if time.Since(linkedAt) > linkTxLogsInterval {
+			linkedAt = time.Now()
+			linkUnlinkedTxLogs(r.storage, r.logger, worker.GetChainName())
+		}
+
 		txLogs, err := r.storage.FindTxLogs(worker.GetChainName(), worker.GetConfirmNum())
 		if err != nil {
 			r.logger.Errorf("ConfirmWorkerTx(), err = %s", err)
@@ -91,8 +146,22 @@ func (r *BridgeSRV) ConfirmWorkerTx(worker workers.IWorker) {
 
 		txHashes := make([]string, 0, len(txLogs))
 		newEvents := make([]*storage.Event, 0)
+		securityTxLogs := make([]*storage.TxLog, 0)
 
 		for _, txLog := range txLogs {
+			// audit txs are kept as history only
+			if txLog.TxType.IsAudit() {
+				if txLog.SwapID == "" && txLog.TxType == storage.TxTypeProposalVote {
+					if err := linkTxLogToSwap(r.storage, txLog); err != nil {
+						r.logger.Warnf("%s tx %s is left unlinked, it is linked again later, err = %s", txLog.TxType, txLog.TxHash, err)
+					}
+				}
+				if txLog.TxType.IsSecurity() {
+					securityTxLogs = append(securityTxLogs, txLog)
+				}
+				txHashes = append(txHashes, txLog.TxHash)
+				continue
+			}
 			// reject swap request if receiver addr and worker chain addr both are r addr
 			// if worker.IsSameAddress(txLog.ReceiverAddr, worker.GetWorkerAddress()) &&
 			// 	!r.laWorker.IsSameAddress(txLog.WorkerChainAddr, r.laWorker.GetWorkerAddress()) {
@@ -113,6 +182,10 @@ func (r *BridgeSRV) ConfirmWorkerTx(worker workers.IWorker) {
 				Status:             txLog.EventStatus,
 				CreateTime:         time.Now().Unix(),
 			}
+			// only deposit carries address of the user, other txs are sent by relayers
+			if txLog.TxType == storage.TxTypeDeposit {
+				newEvent.SenderAddr = txLog.SenderAddr
+			}
 			newEvents = append(newEvents, newEvent)
 			txHashes = append(txHashes, txLog.TxHash)
 		}
@@ -120,12 +193,51 @@ func (r *BridgeSRV) ConfirmWorkerTx(worker workers.IWorker) {
 		//
 		if err := r.storage.ConfirmWorkerTx(worker.GetChainName(), txLogs, txHashes, newEvents); err != nil {
 			r.logger.Errorf("compensate new swap tx error, err=%s", err)
+		} else {
+			for _, txLog := range securityTxLogs {
+				r.handleSecurityTx(worker, txLog)
+			}
 		}
 
 		time.Sleep(2 * time.Second)
 	}
 }
 
+// linkTxLogToSwap finds the swap of tx which doesn't carry destination chain, like vote on proposal
+func linkTxLogToSwap(store txLinkStore, txLog *storage.TxLog) error {
+	events, err := store.GetEventsByDeposit(txLog.OriginChainID, txLog.DepositNonce, txLog.ResourceID)
+	if err != nil {
+		return fmt.Errorf("get events error: %w", err)
+	}
+	if len(events) != 1 {
+		return fmt.Errorf("tx matches %d swaps", len(events))
+	}
+
+	txLog.SwapID = events[0].SwapID
+	txLog.DestinationChainID = events[0].DestinationChainID
+	if err := store.UpdateTxLogSwap(txLog); err != nil {
+		return fmt.Errorf("link to swap %s error: %w", txLog.SwapID, err)
+	}
+	return nil
+}
+
+// linkUnlinkedTxLogs retries to link votes confirmed before their swap was created
+func linkUnlinkedTxLogs(store txLinkStore, logger *logrus.Logger, chain string) {
+	createdAfter := time.Now().Add(-linkTxLogsMaxAge).Unix()
+	txLogs, err := store.GetUnlinkedTxLogs(chain, storage.TxTypeProposalVote, createdAfter, linkTxLogsBatch)
+	if err != nil {
+		logger.Errorf("get unlinked txs of %s error, err = %s", chain, err)
+		return
+	}
+	for _, txLog := range txLogs {
+		if err := linkTxLogToSwap(store, txLog); err != nil {
+			logger.Debugf("%s tx %s is still unlinked, err = %s", txLog.TxType, txLog.TxHash, err)
+			continue
+		}
+		logger.Infof("%s tx %s is linked to swap %s", txLog.TxType, txLog.TxHash, txLog.SwapID)
+	}
+}
+
 // CheckTxSentRoutine ...
 func (r *BridgeSRV) CheckTxSentRoutine(worker workers.IWorker) {
 	for {
@@ -149,11 +261,45 @@ func (r *BridgeSRV) CheckTxSent(worker workers.IWorker) {
 			r.logger.WithFields(logrus.Fields{"function": "CheckTxSent() | UpdateTxSentStatus()"}).Errorln(err)
 			return
 		}
+		if status == storage.TxSentStatusFailed && txSent.TxHash != "" {
+			r.saveRevertReason(worker, txSent)
+		}
+	}
+}
+
+// saveRevertReason stores revert reason of mined failed tx
+func (r *BridgeSRV) saveRevertReason(worker workers.IWorker, txSent *storage.TxSent) {
+	reason, err := worker.GetRevertReason(txSent.TxHash)
+	if err != nil {
+		r.logger.WithFields(logrus.Fields{"function": "CheckTxSent() | GetRevertReason()"}).Errorln(err)
+		return
+	}
+	r.logger.Warnf("tx reverted | chain=%s, tx_hash=%s, reason=%s", txSent.Chain, txSent.TxHash, reason)
+	if err := r.storage.UpdateTxSentErrMsg(txSent, reason); err != nil {
+		r.logger.WithFields(logrus.Fields{"function": "CheckTxSent() | UpdateTxSentErrMsg()"}).Errorln(err)
+	}
+}
+
+// observeSwapDuration records time from the block of the swap deposit to confirmation of its proposal,
+// swaps with deposit stored without block time are not observed
+func (r *BridgeSRV) observeSwapDuration(event *storage.Event) {
+	txLogs, err := r.storage.GetTxLogsBySwapID(event.SwapID)
+	if err != nil {
+		r.logger.Errorf("get tx logs of swap error, swap_id=%s, err=%v", event.SwapID, err)
+		return
+	}
+	for _, txLog := range txLogs {
+		if txLog.TxType == storage.TxTypeDeposit && txLog.Timestamp > 0 {
+			metrics.SwapDuration.WithLabelValues(r.routeName(event)).Observe(float64(time.Now().Unix() - txLog.Timestamp))
+			return
+		}
 	}
+	r.logger.Warnf("swap duration is not observed, no deposit block time, swap_id=%s", event.SwapID)
 }
 
-func (r *BridgeSRV) handleTxSent(chain string, event *storage.Event, txType storage.TxType, backwardStatus storage.EventStatus,
+func (r *BridgeSRV) handleTxSent(worker workers.IWorker, event *storage.Event, txType storage.TxType, backwardStatus storage.EventStatus,
 	failedStatus storage.EventStatus, successStatus storage.EventStatus) {
+	chain := worker.GetChainName()
 	txsSent := r.storage.GetTxsSentByType(chain, txType, event)
 
 	if len(txsSent) == 0 {
@@ -162,31 +308,162 @@ func (r *BridgeSRV) handleTxSent(chain string, event *storage.Event, txType stor
 	}
 	latestTx := txsSent[0]
 	timeElapsed := time.Now().Unix() - latestTx.CreateTime
-	autoRetryTimeout, autoRetryNum := r.getAutoRetryConfig(chain)
-	txStatus := latestTx.Status
 
+	// swap is confirmed when any of sent txs or their replacements is mined
 	for _, txSent := range txsSent {
 		if txSent.Status == storage.TxSentStatusSuccess {
+			if successStatus == storage.EventStatusPassedConfirmed && event.Status != successStatus {
+				r.observeSwapDuration(event)
+			}
 			r.storage.UpdateEventStatus(event, successStatus)
+			r.dropReplacedTxs(txsSent)
 			return
 		}
 	}
-	if txStatus == storage.TxSentStatusFailed {
-		r.storage.UpdateEventStatus(event, failedStatus)
+	// txs are checked and replaced by the worker of their chain only
+	if latestTx.Chain != chain {
 		return
 	}
-	if timeElapsed > autoRetryTimeout && txStatus == storage.TxSentStatusInit {
-		if len(txsSent) >= autoRetryNum {
-			r.storage.UpdateEventStatus(event, failedStatus)
-		} else {
-			r.storage.UpdateEventStatus(event, backwardStatus)
+
+	// swap is never re-queued with a new nonce while any tx of the attempt may still be mined
+	if unmined := unminedTxs(latestAttempt(txsSent)); len(unmined) > 0 {
+		if !r.isNonceDropped(worker, unmined) {
+			if r.canSign() && timeElapsed > r.getReplaceTimeout(worker) {
+				if err := r.replaceTxSent(worker, latestTx); err != nil {
+					r.alertStuckTx(latestTx, err)
+				}
+			}
+			return
+		}
+		for _, txSent := range unmined {
+			r.storage.UpdateTxSentStatus(txSent, storage.TxSentStatusLost)
+			txSent.Status = storage.TxSentStatusLost
+		}
+	}
+
+	kind := failureOf(latestAttempt(txsSent))
+	if kind == "" {
+		return
+	}
+	policy := r.getRetryPolicy(worker, kind)
+	failures := countFailures(txsSent, kind)
+	if kind == failureNotFound {
+		// tx is not lost until timeout of the attempt passes
+		if timeElapsed <= retryDelay(policy, failures) {
+			return
 		}
 		r.storage.UpdateTxSentStatus(latestTx, storage.TxSentStatusLost)
+	} else if failures < policy.MaxAttempts && timeElapsed <= retryDelay(policy, failures) {
+		return
+	}
+
+	if failures >= policy.MaxAttempts {
+		// proposal could be executed or cancelled on chain meanwhile, swap is reconciled with it then
+		if err := r.checkProposalOnChain(worker, event); err != nil {
+			r.logger.Warnf("swap %s is not failed on %s, err = %v", event.SwapID, chain, err)
+			return
+		}
+		r.logger.Warnf("swap %s failed on %s after %d attempts, failure = %s", event.SwapID, chain, failures, kind)
+		r.storage.UpdateEventStatus(event, failedStatus)
+		r.dropReplacedTxs(txsSent)
+		return
 	}
+	r.logger.Infof("retry swap %s on %s, attempt = %d, failure = %s", event.SwapID, chain, failures+1, kind)
+	r.storage.UpdateEventStatus(event, backwardStatus)
+	r.dropReplacedTxs(txsSent)
 }
 
-// !!! TODO !!!
+// unminedTxs returns broadcasted txs of the attempt which may still be mined
+func unminedTxs(attempt []*storage.TxSent) []*storage.TxSent {
+	unmined := make([]*storage.TxSent, 0)
+	for _, txSent := range attempt {
+		if txSent.TxHash != "" && (txSent.Status == storage.TxSentStatusInit || txSent.Status == storage.TxSentStatusPending ||
+			txSent.Status == storage.TxSentStatusNotFound) {
+			unmined = append(unmined, txSent)
+		}
+	}
+	return unmined
+}
+
+// isNonceDropped returns true if nonce of the txs is taken by another confirmed tx, so none of them can be mined
+func (r *BridgeSRV) isNonceDropped(worker workers.IWorker, unmined []*storage.TxSent) bool {
+	used, err := worker.IsTxNonceUsed(unmined[0].TxHash)
+	if err != nil {
+		r.logger.Warnf("check nonce of tx %s on %s error, err = %v", unmined[0].TxHash, worker.GetChainName(), err)
+		return false
+	}
+	if !used {
+		return false
+	}
+	// one of the txs could be mined after statuses were checked
+	for _, txSent := range unmined {
+		if status := worker.GetSentTxStatus(txSent.TxHash); status == storage.TxSentStatusSuccess || status == storage.TxSentStatusFailed {
+			r.storage.UpdateTxSentStatus(txSent, status)
+			return false
+		}
+	}
+	return true
+}
+
+// alertStuckTx raises alert once for tx which can't be replaced, swap waits for the tx or for operator then
+func (r *BridgeSRV) alertStuckTx(txSent *storage.TxSent, err error) {
+	r.Lock()
+	alerted := r.stuckTxs[txSent.TxHash]
+	r.stuckTxs[txSent.TxHash] = true
+	r.Unlock()
+
+	if alerted {
+		r.logger.Warnf("replace stuck tx %s on %s failed: %s", txSent.TxHash, txSent.Chain, err)
+		return
+	}
+	r.alerter.Raise(alerts.LevelCritical, txSent.Chain, "stuck tx",
+		fmt.Sprintf("tx %s of swap %s can't be replaced, swap is not re-queued until the tx is mined or dropped, err = %v",
+			txSent.TxHash, txSent.SwapID, err))
+}
+
+// replaceTxSent re-sends stuck tx with bumped gas price and records replacement against the same swap
+func (r *BridgeSRV) replaceTxSent(worker workers.IWorker, txSent *storage.TxSent) error {
+	txHash, err := worker.ReplaceTx(txSent.TxHash)
+	if err != nil {
+		return err
+	}
 
-func (r *BridgeSRV) getAutoRetryConfig(chain string) (int64, int) {
-	return 1800, 1
+	r.logger.Infof("stuck tx replaced | chain=%s, swap_id=%s, tx_hash=%s, replaced_tx_hash=%s",
+		txSent.Chain, txSent.SwapID, txHash, txSent.TxHash)
+	return r.storage.CreateTxSent(&storage.TxSent{
+		Chain:          txSent.Chain,
+		Type:           txSent.Type,
+		SwapID:         txSent.SwapID,
+		TxHash:         txHash,
+		ReplacedTxHash: txSent.TxHash,
+		CreateTime:     time.Now().Unix(),
+	})
+}
+
+// dropReplacedTxs marks txs which will never be mined as lost, once another tx of the swap is mined
+func (r *BridgeSRV) dropReplacedTxs(txsSent []*storage.TxSent) {
+	for _, txSent := range txsSent {
+		if txSent.Status == storage.TxSentStatusInit || txSent.Status == storage.TxSentStatusPending ||
+			txSent.Status == storage.TxSentStatusNotFound {
+			r.storage.UpdateTxSentStatus(txSent, storage.TxSentStatusLost)
+		}
+	}
+}
+
+// latestAttempt returns the latest sent tx with all its replacements, txsSent are ordered from the newest
+func latestAttempt(txsSent []*storage.TxSent) []*storage.TxSent {
+	for i, txSent := range txsSent {
+		if txSent.ReplacedTxHash == "" {
+			return txsSent[:i+1]
+		}
+	}
+	return txsSent
+}
+
+// getReplaceTimeout returns seconds after which pending tx is replaced with bumped gas price
+func (r *BridgeSRV) getReplaceTimeout(worker workers.IWorker) int64 {
+	if timeout := worker.GetConfig().ReplaceTimeout; timeout > 0 {
+		return timeout
+	}
+	return defaultReplaceTimeout
 }
@@ -0,0 +1,112 @@
+package rlr
+
+import (
+	"io"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+// fakeTxLinkStore keeps swaps in memory and records txs linked to them
+type fakeTxLinkStore struct {
+	events   []*storage.Event
+	unlinked []*storage.TxLog
+	linked   []*storage.TxLog
+}
+
+func (s *fakeTxLinkStore) GetEventsByDeposit(originChainID string, depositNonce uint64, resourceID string) ([]*storage.Event, error) {
+	events := make([]*storage.Event, 0)
+	for _, event := range s.events {
+		if event.OriginChainID == originChainID && event.DepositNonce == depositNonce && event.ResourceID == resourceID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s *fakeTxLinkStore) GetUnlinkedTxLogs(chain string, txType storage.TxType, createdAfter int64, limit int) ([]*storage.TxLog, error) {
+	return s.unlinked, nil
+}
+
+func (s *fakeTxLinkStore) UpdateTxLogSwap(txLog *storage.TxLog) error {
+	s.linked = append(s.linked, txLog)
+	return nil
+}
+
+func vote(nonce uint64) *storage.TxLog {
+	return &storage.TxLog{Chain: "LA", TxType: storage.TxTypeProposalVote, TxHash: "0x1", OriginChainID: "01", DepositNonce: nonce, ResourceID: "aa"}
+}
+
+func TestLinkTxLogToSwap(t *testing.T) {
+	events := []*storage.Event{
+		{SwapID: "0xs1", OriginChainID: "01", DestinationChainID: "02", DepositNonce: 1, ResourceID: "aa"},
+		// the same deposit sent to two destination chains
+		{SwapID: "0xs2", OriginChainID: "01", DestinationChainID: "02", DepositNonce: 2, ResourceID: "aa"},
+		{SwapID: "0xs3", OriginChainID: "01", DestinationChainID: "03", DepositNonce: 2, ResourceID: "aa"},
+		{SwapID: "0xs4", OriginChainID: "01", DestinationChainID: "02", DepositNonce: 3, ResourceID: "bb"},
+	}
+
+	tests := []struct {
+		name            string
+		txLog           *storage.TxLog
+		wantSwap        string
+		wantDestination string
+	}{
+		{"one swap", vote(1), "0xs1", "02"},
+		{"no swap", vote(3), "", ""},
+		{"several swaps", vote(2), "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &fakeTxLinkStore{events: events}
+			err := linkTxLogToSwap(store, tt.txLog)
+			if tt.wantSwap == "" {
+				if err == nil {
+					t.Fatalf("want error, tx is linked to %s", tt.txLog.SwapID)
+				}
+				if len(store.linked) != 0 || tt.txLog.SwapID != "" || tt.txLog.DestinationChainID != "" {
+					t.Fatalf("tx is linked to %q with destination %q", tt.txLog.SwapID, tt.txLog.DestinationChainID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.txLog.SwapID != tt.wantSwap || tt.txLog.DestinationChainID != tt.wantDestination {
+				t.Fatalf("got swap %q with destination %q, want %q with %q", tt.txLog.SwapID, tt.txLog.DestinationChainID, tt.wantSwap, tt.wantDestination)
+			}
+			if len(store.linked) != 1 || store.linked[0] != tt.txLog {
+				t.Fatalf("got %d txs updated, want the linked one", len(store.linked))
+			}
+		})
+	}
+}
+
+func TestLinkUnlinkedTxLogs(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	store := &fakeTxLinkStore{
+		events: []*storage.Event{
+			{SwapID: "0xs1", OriginChainID: "01", DestinationChainID: "02", DepositNonce: 1, ResourceID: "aa"},
+			{SwapID: "0xs2", OriginChainID: "01", DestinationChainID: "02", DepositNonce: 2, ResourceID: "aa"},
+			{SwapID: "0xs3", OriginChainID: "01", DestinationChainID: "03", DepositNonce: 2, ResourceID: "aa"},
+		},
+		unlinked: []*storage.TxLog{vote(2), vote(1), vote(3)},
+	}
+
+	linkUnlinkedTxLogs(store, logger, "LA")
+
+	// txs matching no or several swaps are left for the next run
+	if len(store.linked) != 1 || store.linked[0].DepositNonce != 1 || store.linked[0].SwapID != "0xs1" {
+		t.Fatalf("got linked txs %+v, want only vote with nonce 1", store.linked)
+	}
+	for _, txLog := range store.unlinked {
+		if txLog.DepositNonce != 1 && txLog.SwapID != "" {
+			t.Fatalf("vote with nonce %d is linked to %s", txLog.DepositNonce, txLog.SwapID)
+		}
+	}
+}
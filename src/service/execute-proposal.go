@@ -1,6 +1,7 @@
 package rlr
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -16,12 +17,15 @@ func (r *BridgeSRV) emitProposal(worker workers.IWorker) {
 		for _, event := range events {
 			if event.Status == storage.EventStatusPassedInitConfrimed &&
 				worker.GetDestinationID() == event.DestinationChainID { //send tx where dest chainID matches
+				if !r.canSendProposal(worker, event) {
+					continue
+				}
 				r.logger.Infoln("attempting to send execute proposal")
 				if _, err := r.sendExecuteProposal(worker, event); err != nil {
 					r.logger.Errorf("submit claim failed: %s", err)
 				}
 			} else {
-				r.handleTxSent(worker.GetChainName(), event, storage.TxTypePassed,
+				r.handleTxSent(worker, event, storage.TxTypePassed,
 					storage.EventStatusPassedInitConfrimed, storage.EventStatusPassedFailed, storage.EventStatusPassedConfirmed)
 			}
 			time.Sleep(2 * time.Second)
@@ -39,6 +43,19 @@ func (r *BridgeSRV) sendExecuteProposal(worker workers.IWorker, event *storage.E
 		CreateTime: time.Now().Unix(),
 	}
 
+	if !r.canSign() {
+		return "", fmt.Errorf("relayer is in %s mode", r.GetMode())
+	}
+
+	if r.isPaused(worker.GetChainName()) {
+		return "", fmt.Errorf("bridge contract of %s is paused", worker.GetChainName())
+	}
+
+	// proposal could be executed already, e.g. after database restore or manual status edit
+	if err := r.checkProposalOnChain(worker, event); err != nil {
+		return "", err
+	}
+
 	r.logger.Infof("Execute parameters:  depositNonce(%d) | sender(%s) | outAmount(%s) | resourceID(%s) | chainID(%s)\n",
 		event.DepositNonce, event.ReceiverAddr, event.OutAmount, event.ResourceID, worker.GetChainName())
 	if worker.GetChainName() == "LA" {
@@ -59,6 +76,13 @@ func (r *BridgeSRV) sendExecuteProposal(worker workers.IWorker, event *storage.E
 	if err != nil {
 		txSent.ErrMsg = err.Error()
 		txSent.TxHash = txHash
+		// reverted in simulation, no gas spent, it is retried as reverted tx since revert could be temporary,
+		// e.g. contract paused or proposal executed by another relayer meanwhile
+		var revertErr *workers.RevertError
+		if errors.As(err, &revertErr) {
+			txSent.Status = storage.TxSentStatusReverted
+			txSent.ErrMsg = revertErr.Reason
+		}
 		r.storage.UpdateEventStatus(event, storage.EventStatusPassedSentFailed)
 		r.storage.CreateTxSent(txSent)
 		return "", fmt.Errorf("could not send claim tx: %w", err)
@@ -75,3 +99,28 @@ func (r *BridgeSRV) sendExecuteProposal(worker workers.IWorker, event *storage.E
 	return txSent.TxHash, nil
 
 }
+
+// checkProposalOnChain returns error if proposal must not be sent, event is reconciled
+// with on-chain status of the proposal when it is executed or cancelled already
+func (r *BridgeSRV) checkProposalOnChain(worker workers.IWorker, event *storage.Event) error {
+	status, err := worker.GetProposalStatus(event.DepositNonce, utils.StringToBytes8(event.OriginChainID), utils.StringToBytes8(event.DestinationChainID),
+		utils.StringToBytes32(event.ResourceID), event.ReceiverAddr, event.OutAmount)
+	if err != nil {
+		return fmt.Errorf("could not get proposal status: %w", err)
+	}
+
+	switch status {
+	case storage.ProposalStatusExecuted:
+		r.logger.Warnf("proposal already executed on chain, swap_id=%s, chain=%s", event.SwapID, worker.GetChainName())
+		if event.Status != storage.EventStatusPassedConfirmed {
+			r.observeSwapDuration(event)
+		}
+		r.storage.UpdateEventStatus(event, storage.EventStatusPassedConfirmed)
+		return fmt.Errorf("proposal %s already executed", event.SwapID)
+	case storage.ProposalStatusCancelled:
+		r.logger.Warnf("proposal cancelled on chain, swap_id=%s, chain=%s", event.SwapID, worker.GetChainName())
+		r.storage.UpdateEventStatus(event, storage.EventStatusPassedFailed)
+		return fmt.Errorf("proposal %s cancelled", event.SwapID)
+	}
+	return nil
+}
@@ -33,6 +33,17 @@ func (f *FetcherSrv) Run() {
 	go f.collector()
 }
 
+// Chains returns names of chains with gas price api configured
+func (f *FetcherSrv) Chains() []string {
+	chains := make([]string, 0, len(f.chainFetCfgs))
+	for _, cfg := range f.chainFetCfgs {
+		if cfg.URL != "" {
+			chains = append(chains, cfg.ChainName)
+		}
+	}
+	return chains
+}
+
 func (f *FetcherSrv) collector() {
 	for {
 		f.getAllGasPrice()
@@ -0,0 +1,157 @@
+package rlr
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+)
+
+const (
+	// time for all readiness checks, component which is not checked in time is unhealthy
+	readinessTimeout = 5 * time.Second
+	// blocks watcher may be behind the chain if max_lag is not configured
+	defaultMaxLag = 100
+	// seconds since the last fetched gas price if gas_price_max_age is not configured
+	defaultGasPriceMaxAge = 600
+)
+
+// componentCheck is readiness check of the component, status is reported as is if check doesn't finish in time
+type componentCheck struct {
+	status models.ComponentStatus
+	run    func() models.ComponentStatus
+}
+
+// Readiness checks connection to database, providers of chains, lag of watcher and freshness of gas prices
+func (r *BridgeSRV) Readiness() *models.Readiness {
+	checks := []componentCheck{{
+		status: models.ComponentStatus{Component: "database"},
+		run:    r.checkDatabase,
+	}}
+	for _, worker := range r.Workers {
+		worker := worker
+		checks = append(checks, componentCheck{
+			status: models.ComponentStatus{Component: "rpc", Chain: worker.GetChainName()},
+			run:    func() models.ComponentStatus { return r.checkRPC(worker) },
+		}, componentCheck{
+			status: models.ComponentStatus{Component: "lag", Chain: worker.GetChainName()},
+			run:    func() models.ComponentStatus { return r.checkLag(worker) },
+		})
+	}
+	for _, chain := range r.Fetcher.Chains() {
+		if worker, ok := r.Workers[chain]; ok {
+			checks = append(checks, componentCheck{
+				status: models.ComponentStatus{Component: "gas_price", Chain: chain},
+				run:    func() models.ComponentStatus { return r.checkGasPrice(worker) },
+			})
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	components := make([]models.ComponentStatus, len(checks))
+	for i, check := range checks {
+		components[i] = check.status
+		components[i].Error = "check timed out"
+
+		wg.Add(1)
+		go func(i int, check componentCheck) {
+			defer wg.Done()
+			status := check.run()
+			mu.Lock()
+			components[i] = status
+			mu.Unlock()
+		}(i, check)
+	}
+
+	finished := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(finished)
+	}()
+	select {
+	case <-finished:
+	case <-time.After(readinessTimeout):
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	readiness := &models.Readiness{
+		Ready:      true,
+		Components: append([]models.ComponentStatus(nil), components...),
+	}
+	for _, component := range readiness.Components {
+		if !component.Healthy {
+			readiness.Ready = false
+		}
+	}
+	return readiness
+}
+
+func (r *BridgeSRV) checkDatabase() models.ComponentStatus {
+	status := models.ComponentStatus{Component: "database"}
+	if err := r.storage.Ping(); err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Healthy = true
+	return status
+}
+
+func (r *BridgeSRV) checkRPC(worker workers.IWorker) models.ComponentStatus {
+	status := models.ComponentStatus{Component: "rpc", Chain: worker.GetChainName()}
+	height, err := worker.GetHeight()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	status.Healthy = true
+	status.Value = height
+	return status
+}
+
+// checkLag compares height of the last block scanned by watcher with the chain head
+func (r *BridgeSRV) checkLag(worker workers.IWorker) models.ComponentStatus {
+	status := models.ComponentStatus{Component: "lag", Chain: worker.GetChainName()}
+	height, err := worker.GetHeight()
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+
+	maxLag := worker.GetConfig().MaxLag
+	if maxLag <= 0 {
+		maxLag = defaultMaxLag
+	}
+	status.Value = height - r.storage.GetCurrentBlockLog(worker.GetChainName()).Height
+	if status.Value > maxLag {
+		status.Error = fmt.Sprintf("watcher is %d blocks behind, max = %d", status.Value, maxLag)
+		return status
+	}
+	status.Healthy = true
+	return status
+}
+
+// checkGasPrice checks age of the last gas price fetched for the chain
+func (r *BridgeSRV) checkGasPrice(worker workers.IWorker) models.ComponentStatus {
+	status := models.ComponentStatus{Component: "gas_price", Chain: worker.GetChainName()}
+	maxAge := worker.GetConfig().GasPriceMaxAge
+	if maxAge <= 0 {
+		maxAge = defaultGasPriceMaxAge
+	}
+
+	gasPrice := r.storage.GetGasPrice(worker.GetChainName())
+	if gasPrice.UpdateTime == 0 {
+		status.Error = "gas price is not fetched"
+		return status
+	}
+	status.Value = time.Now().Unix() - gasPrice.UpdateTime
+	if status.Value > maxAge {
+		status.Error = fmt.Sprintf("gas price is %ds old, max = %ds", status.Value, maxAge)
+		return status
+	}
+	status.Healthy = true
+	return status
+}
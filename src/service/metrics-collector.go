@@ -0,0 +1,81 @@
+package rlr
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/metrics"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const metricsCollectInterval = 15 * time.Second
+
+// MetricsCollector periodically sets gauges of chains and database state,
+// counters and histograms are updated where things happen
+func (r *BridgeSRV) MetricsCollector() {
+	for {
+		for _, worker := range r.Workers {
+			r.collectChainMetrics(worker)
+		}
+		r.collectStorageMetrics()
+		time.Sleep(metricsCollectInterval)
+	}
+}
+
+func (r *BridgeSRV) collectChainMetrics(worker workers.IWorker) {
+	chain := worker.GetChainName()
+
+	synced := r.storage.GetCurrentBlockLog(chain).Height
+	metrics.SyncedHeight.WithLabelValues(chain).Set(float64(synced))
+	if height, err := worker.GetHeight(); err == nil && height > 0 {
+		metrics.HeadHeight.WithLabelValues(chain).Set(float64(height))
+		metrics.Lag.WithLabelValues(chain).Set(float64(height - synced))
+	}
+
+	if balance, err := worker.GetWorkerBalance(); err != nil {
+		r.logger.Errorf("get balance of relayer on %s error, err = %v", chain, err)
+	} else {
+		ether, _ := new(big.Float).Quo(new(big.Float).SetInt(balance), big.NewFloat(params.Ether)).Float64()
+		metrics.RelayerBalance.WithLabelValues(chain).Set(ether)
+	}
+
+	if price, err := strconv.ParseFloat(r.storage.GetGasPrice(chain).Price, 64); err == nil {
+		metrics.GasPrice.WithLabelValues(chain).Set(price)
+	}
+
+	counts, err := r.storage.CountTxsSentByStatus(chain)
+	if err != nil {
+		r.logger.Errorf("count sent txs of %s error, err = %v", chain, err)
+		return
+	}
+	// statuses without txs are set too, so gauge drops to zero
+	for _, status := range []storage.TxStatus{storage.TxSentStatusInit, storage.TxSentStatusNotFound, storage.TxSentStatusPending,
+		storage.TxSentStatusFailed, storage.TxSentStatusSuccess, storage.TxSentStatusLost, storage.TxSentStatusReverted} {
+		metrics.TxsSent.WithLabelValues(chain, string(status)).Set(float64(counts[status]))
+	}
+}
+
+func (r *BridgeSRV) collectStorageMetrics() {
+	counts, err := r.storage.CountEventsByStatus()
+	if err != nil {
+		r.logger.Errorf("count events error, err = %v", err)
+		return
+	}
+	metrics.Events.Reset()
+	for status, count := range counts {
+		metrics.Events.WithLabelValues(string(status)).Set(float64(count))
+	}
+}
+
+// setStuckSwapsMetric sets number of stuck swaps by route
+func setStuckSwapsMetric(stuck map[string]*models.StuckSwap) {
+	metrics.StuckSwaps.Reset()
+	for _, swap := range stuck {
+		metrics.StuckSwaps.WithLabelValues(swap.Route).Inc()
+	}
+}
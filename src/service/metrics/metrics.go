@@ -0,0 +1,89 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "bridge"
+
+var (
+	// HeadHeight is height of the chain reported by its provider
+	HeadHeight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "head_height",
+		Help:      "Height of the chain head reported by provider.",
+	}, []string{"chain"})
+
+	// SyncedHeight is height of the last block scanned by watcher
+	SyncedHeight = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "synced_height",
+		Help:      "Height of the last block scanned by watcher.",
+	}, []string{"chain"})
+
+	// Lag is number of blocks watcher is behind the chain
+	Lag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "lag_blocks",
+		Help:      "Number of blocks watcher is behind the chain head.",
+	}, []string{"chain"})
+
+	// Events is number of swaps by status
+	Events = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "events",
+		Help:      "Number of swaps in 'events' by status.",
+	}, []string{"status"})
+
+	// TxsSent is number of relayer txs by chain and status
+	TxsSent = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "txs_sent",
+		Help:      "Number of relayer txs in 'tx_sents' by chain and status.",
+	}, []string{"chain", "status"})
+
+	// StuckSwaps is number of swaps not finished within SLA by route
+	StuckSwaps = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "stuck_swaps",
+		Help:      "Number of swaps not finished within SLA of their route.",
+	}, []string{"route"})
+
+	// SendDuration is time of signing, simulating and sending relayer tx
+	SendDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "send_duration_seconds",
+		Help:      "Time of signing, simulating and sending relayer tx.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10),
+	}, []string{"chain"})
+
+	// RPCErrors is number of failed RPC calls by method and provider
+	RPCErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_errors_total",
+		Help:      "Number of failed RPC calls by chain, method and provider.",
+	}, []string{"chain", "method", "provider"})
+
+	// RelayerBalance is native balance of relayer address in ether
+	RelayerBalance = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "relayer_balance",
+		Help:      "Native balance of relayer address in ether.",
+	}, []string{"chain"})
+
+	// GasPrice is gas price of the chain in gwei taken by fetcher
+	GasPrice = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "gas_price_gwei",
+		Help:      "Gas price of the chain in gwei taken by fetcher.",
+	}, []string{"chain"})
+
+	// SwapDuration is time from the block of deposit to confirmed execution of the proposal
+	SwapDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "swap_duration_seconds",
+		Help:      "Time from the block of deposit to PASSED_CONFIRMED status of the swap.",
+		Buckets:   prometheus.ExponentialBuckets(15, 2, 10),
+	}, []string{"route"})
+)
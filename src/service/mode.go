@@ -0,0 +1,85 @@
+package rlr
+
+import (
+	"fmt"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+)
+
+// GetMode returns operating mode of relayer, it is read from database on each call,
+// so mode switched on one instance applies to all of them. Last known mode is used if database fails
+func (r *BridgeSRV) GetMode() string {
+	mode, err := r.storage.GetSetting(storage.SettingMode)
+	r.Lock()
+	defer r.Unlock()
+	if err != nil {
+		r.logger.Errorf("get relayer mode error, keep %q, err = %v", r.mode, err)
+		return r.mode
+	}
+	if mode != "" && mode != r.mode {
+		r.logger.Warnf("relayer mode changed from %q to %q", r.mode, mode)
+		r.mode = mode
+	}
+	return r.mode
+}
+
+// SetMode switches operating mode of all relayer instances
+func (r *BridgeSRV) SetMode(mode string) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+	if err := r.storage.SaveSetting(storage.SettingMode, mode); err != nil {
+		return err
+	}
+	r.GetMode()
+	return nil
+}
+
+// initMode sets mode from config if it is not saved in database yet, saved mode is kept,
+// so relayer switched to observe mode by admin does not start signing after restart
+func (r *BridgeSRV) initMode(mode string) error {
+	if err := validateMode(mode); err != nil {
+		return err
+	}
+	r.mode = mode
+
+	saved, err := r.storage.GetSetting(storage.SettingMode)
+	if err != nil {
+		return err
+	}
+	if saved == "" {
+		return r.storage.SaveSetting(storage.SettingMode, mode)
+	}
+	if saved != mode {
+		r.logger.Warnf("relayer mode %q from config is ignored, mode %q is set by admin", mode, saved)
+	}
+	r.mode = saved
+	return nil
+}
+
+func validateMode(mode string) error {
+	switch mode {
+	case models.ModeNormal, models.ModeObserve, models.ModeDrain:
+		return nil
+	}
+	return fmt.Errorf("unknown mode %q, must be one of %s, %s, %s", mode, models.ModeNormal, models.ModeObserve, models.ModeDrain)
+}
+
+// canSign returns false if relayer must not sign and send any tx
+func (r *BridgeSRV) canSign() bool {
+	return r.GetMode() != models.ModeObserve
+}
+
+// canSendProposal returns false if proposal of the swap must not be sent in current mode,
+// in drain mode only swaps sent already are finished
+func (r *BridgeSRV) canSendProposal(worker workers.IWorker, event *storage.Event) bool {
+	switch r.GetMode() {
+	case models.ModeObserve:
+		return false
+	case models.ModeDrain:
+		return len(r.storage.GetTxsSentByType(worker.GetChainName(), storage.TxTypePassed, event)) != 0
+	}
+	return true
+}
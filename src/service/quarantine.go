@@ -0,0 +1,74 @@
+package rlr
+
+import (
+	"fmt"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+// GetQuarantinedLogs returns logs of the chain waiting for re-parse, all chains if chain is empty
+func (r *BridgeSRV) GetQuarantinedLogs(chain string, limit int) ([]*storage.QuarantinedLog, error) {
+	return r.storage.GetQuarantinedLogs(chain, storage.QuarantineStatusQuarantined, limit)
+}
+
+// ReparseQuarantinedLogs parses quarantined logs of the chain again, all chains if chain is empty
+func (r *BridgeSRV) ReparseQuarantinedLogs(chain string, limit int) ([]*models.ReparseResult, error) {
+	logs, err := r.storage.GetQuarantinedLogs(chain, storage.QuarantineStatusQuarantined, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*models.ReparseResult, 0, len(logs))
+	for _, log := range logs {
+		results = append(results, r.reparseQuarantinedLog(log))
+	}
+	return results, nil
+}
+
+// ReparseQuarantinedLog parses quarantined log by id again
+func (r *BridgeSRV) ReparseQuarantinedLog(id int64) (*models.ReparseResult, error) {
+	log, err := r.storage.GetQuarantinedLog(id)
+	if err != nil {
+		return nil, err
+	}
+	if log.Status != storage.QuarantineStatusQuarantined {
+		return nil, fmt.Errorf("log %d is %s already", id, log.Status)
+	}
+	return r.reparseQuarantinedLog(log), nil
+}
+
+// reparseQuarantinedLog moves successfully parsed log into 'tx_logs' or keeps the new error
+func (r *BridgeSRV) reparseQuarantinedLog(log *storage.QuarantinedLog) *models.ReparseResult {
+	result := &models.ReparseResult{
+		ID:     log.ID,
+		Chain:  log.Chain,
+		TxHash: log.TxHash,
+	}
+
+	worker, ok := r.Workers[log.Chain]
+	if !ok {
+		result.Error = fmt.Sprintf("worker of chain %s is not configured", log.Chain)
+		return result
+	}
+
+	txLog, err := worker.ReparseLog(log)
+	if err != nil {
+		result.Error = err.Error()
+		if err := r.storage.UpdateQuarantinedLogError(log.ID, result.Error); err != nil {
+			r.logger.Errorf("update quarantined log %d error, err = %v", log.ID, err)
+		}
+		return result
+	}
+
+	if err := r.storage.ReleaseQuarantinedLog(log.ID, txLog); err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Released = true
+	if txLog != nil {
+		result.TxType = string(txLog.TxType)
+	}
+	r.logger.Infof("quarantined log %d of %s(tx %s) is released", log.ID, log.Chain, log.TxHash)
+	return result
+}
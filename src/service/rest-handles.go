@@ -2,17 +2,19 @@ package rlr
 
 import (
 	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
 )
 
 // Status ...
-func (r *BridgeSRV) StatusOfWorkers() (map[string]*models.WorkerStatus, error) {
+func (r *BridgeSRV) StatusOfWorkers() (*models.RelayerStatus, error) {
 	// get blockchain heights from workers and from database
 	workers := make(map[string]*models.WorkerStatus)
 	for _, w := range r.Workers {
 		status, err := w.GetStatus()
 		if err != nil {
+			// chain is reported with error, so status of other chains is still available
 			r.logger.Errorf("While get status for worker = %s, err = %v", w.GetChainName(), err)
-			return nil, err
+			status = &models.WorkerStatus{Error: err.Error()}
 		}
 		workers[w.GetChainName()] = status
 	}
@@ -20,9 +22,27 @@ func (r *BridgeSRV) StatusOfWorkers() (map[string]*models.WorkerStatus, error) {
 	for name, w := range workers {
 		blocks := r.storage.GetCurrentBlockLog(name)
 		w.SyncHeight = blocks.Height
+		w.Retry = r.Workers[name].GetConfig().Retry
+		w.Paused = r.isPaused(name)
+		w.Headers = headersStatus(r.storage.GetBlockLogs(name), r.Workers[name].GetBlockHistory())
 	}
 
-	return workers, nil
+	return &models.RelayerStatus{
+		Mode:    r.GetMode(),
+		Workers: workers,
+	}, nil
+}
+
+// headersStatus returns window of stored headers, blockLogs are ordered from the newest
+func headersStatus(blockLogs []*storage.BlockLog, history int64) models.HeadersStatus {
+	status := models.HeadersStatus{History: history, Stored: len(blockLogs)}
+	if len(blockLogs) == 0 {
+		return status
+	}
+	newest, oldest := blockLogs[0], blockLogs[len(blockLogs)-1]
+	status.Newest = &models.BlockHeader{Height: newest.Height, Hash: newest.BlockHash}
+	status.Oldest = &models.BlockHeader{Height: oldest.Height, Hash: oldest.BlockHash}
+	return status
 }
 
 func (r *BridgeSRV) GetTxSent(txHash string) (string, error) {
@@ -35,6 +55,17 @@ func (r *BridgeSRV) GetTxSent(txHash string) (string, error) {
 	return txSent, nil
 }
 
+// GetTxSentDetails returns PASSED tx of the swap with its status and error
+func (r *BridgeSRV) GetTxSentDetails(txHash string) (*storage.TxSent, error) {
+	txSent, err := r.storage.GetTxSentByDepositTxHash(txHash)
+	if err != nil {
+		r.logger.Errorf("GetTxSentDetails, txHash: %v, failed with error: %v", txHash, err)
+		return nil, err
+	}
+
+	return txSent, nil
+}
+
 // CreateNewBindRequest ...
 func (r *BridgeSRV) CreateNewBindRequest() {}
 
@@ -0,0 +1,31 @@
+package rlr
+
+import (
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+func TestHeadersStatus(t *testing.T) {
+	blockLogs := []*storage.BlockLog{
+		{Height: 102, BlockHash: "0x102"},
+		{Height: 101, BlockHash: "0x101"},
+		{Height: 100, BlockHash: "0x100"},
+	}
+
+	status := headersStatus(blockLogs, 100)
+	if status.History != 100 || status.Stored != 3 {
+		t.Fatalf("got history %d with %d stored, want 100 with 3", status.History, status.Stored)
+	}
+	if status.Newest == nil || status.Newest.Height != 102 || status.Newest.Hash != "0x102" {
+		t.Fatalf("got newest %+v", status.Newest)
+	}
+	if status.Oldest == nil || status.Oldest.Height != 100 || status.Oldest.Hash != "0x100" {
+		t.Fatalf("got oldest %+v", status.Oldest)
+	}
+
+	empty := headersStatus(nil, 100)
+	if empty.History != 100 || empty.Stored != 0 || empty.Newest != nil || empty.Oldest != nil {
+		t.Fatalf("got %+v for no stored headers", empty)
+	}
+}
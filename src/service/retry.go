@@ -0,0 +1,79 @@
+package rlr
+
+import (
+	"math"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+)
+
+// failureKind is a reason why sent tx did not confirm the swap
+type failureKind string
+
+const (
+	failureSendError failureKind = "send_error"
+	failureReverted  failureKind = "reverted"
+	failureNotFound  failureKind = "not_found"
+)
+
+// getRetryPolicy returns auto-retry policy of the chain for the kind of failure
+func (r *BridgeSRV) getRetryPolicy(worker workers.IWorker, kind failureKind) models.RetryPolicy {
+	retry := worker.GetConfig().Retry
+	switch kind {
+	case failureSendError:
+		return retry.SendError
+	case failureReverted:
+		return retry.Reverted
+	default:
+		return retry.NotFound
+	}
+}
+
+// retryDelay returns seconds to wait after the attempt, growing by backoff multiplier with each failure
+func retryDelay(policy models.RetryPolicy, failures int) int64 {
+	if failures < 1 {
+		failures = 1
+	}
+	backoff := policy.Backoff
+	if backoff < 1 {
+		backoff = 1
+	}
+	return int64(float64(policy.Timeout) * math.Pow(backoff, float64(failures-1)))
+}
+
+// failureOf returns kind of failure of the attempt(sent tx with its replacements), empty if it is still in flight.
+// Tx reverted in simulation counts as reverted one, other txs without hash are send errors
+func failureOf(attempt []*storage.TxSent) failureKind {
+	for _, txSent := range attempt {
+		if txSent.Status == storage.TxSentStatusReverted {
+			return failureReverted
+		}
+		if txSent.TxHash == "" {
+			return failureSendError
+		}
+	}
+	for _, txSent := range attempt {
+		if txSent.Status == storage.TxSentStatusFailed {
+			return failureReverted
+		}
+	}
+	switch attempt[0].Status {
+	case storage.TxSentStatusInit, storage.TxSentStatusNotFound, storage.TxSentStatusLost:
+		return failureNotFound
+	}
+	return ""
+}
+
+// countFailures returns number of attempts failed with the kind of failure, txsSent are ordered from the newest
+func countFailures(txsSent []*storage.TxSent, kind failureKind) int {
+	num := 0
+	for len(txsSent) > 0 {
+		attempt := latestAttempt(txsSent)
+		if failureOf(attempt) == kind {
+			num++
+		}
+		txsSent = txsSent[len(attempt):]
+	}
+	return num
+}
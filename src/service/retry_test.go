@@ -0,0 +1,107 @@
+package rlr
+
+import (
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   models.RetryPolicy
+		failures int
+		want     int64
+	}{
+		{"first failure", models.RetryPolicy{Timeout: 60, Backoff: 2}, 1, 60},
+		{"no failures yet", models.RetryPolicy{Timeout: 60, Backoff: 2}, 0, 60},
+		{"third failure", models.RetryPolicy{Timeout: 60, Backoff: 2}, 3, 240},
+		{"fractional backoff", models.RetryPolicy{Timeout: 100, Backoff: 1.5}, 3, 225},
+		{"no backoff", models.RetryPolicy{Timeout: 60}, 5, 60},
+		{"backoff below one", models.RetryPolicy{Timeout: 60, Backoff: 0.5}, 3, 60},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryDelay(tt.policy, tt.failures); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func txSent(hash string, status storage.TxStatus, replaced string) *storage.TxSent {
+	return &storage.TxSent{TxHash: hash, Status: status, ReplacedTxHash: replaced}
+}
+
+func TestFailureOf(t *testing.T) {
+	tests := []struct {
+		name    string
+		attempt []*storage.TxSent
+		want    failureKind
+	}{
+		{"send error", []*storage.TxSent{txSent("", storage.TxSentStatusFailed, "")}, failureSendError},
+		{"send error not checked yet", []*storage.TxSent{txSent("", storage.TxSentStatusInit, "")}, failureSendError},
+		{"send error of replacement", []*storage.TxSent{
+			txSent("", storage.TxSentStatusFailed, "0x1"),
+			txSent("0x1", storage.TxSentStatusPending, ""),
+		}, failureSendError},
+		{"reverted", []*storage.TxSent{txSent("0x1", storage.TxSentStatusFailed, "")}, failureReverted},
+		{"reverted in simulation", []*storage.TxSent{txSent("", storage.TxSentStatusReverted, "")}, failureReverted},
+		{"replaced tx reverted", []*storage.TxSent{
+			txSent("0x2", storage.TxSentStatusLost, "0x1"),
+			txSent("0x1", storage.TxSentStatusFailed, ""),
+		}, failureReverted},
+		{"not found", []*storage.TxSent{txSent("0x1", storage.TxSentStatusNotFound, "")}, failureNotFound},
+		{"lost", []*storage.TxSent{txSent("0x1", storage.TxSentStatusLost, "")}, failureNotFound},
+		{"init", []*storage.TxSent{txSent("0x1", storage.TxSentStatusInit, "")}, failureNotFound},
+		{"pending", []*storage.TxSent{txSent("0x1", storage.TxSentStatusPending, "")}, ""},
+		{"success", []*storage.TxSent{
+			txSent("0x2", storage.TxSentStatusSuccess, "0x1"),
+			txSent("0x1", storage.TxSentStatusLost, ""),
+		}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := failureOf(tt.attempt); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountFailures(t *testing.T) {
+	// ordered from the newest, replacement goes before the tx it replaced
+	txsSent := []*storage.TxSent{
+		txSent("0x5", storage.TxSentStatusPending, ""),
+		txSent("0x4", storage.TxSentStatusFailed, "0x3"),
+		txSent("0x3", storage.TxSentStatusLost, ""),
+		txSent("", storage.TxSentStatusFailed, ""),
+		txSent("", storage.TxSentStatusReverted, ""),
+		txSent("0x2", storage.TxSentStatusLost, ""),
+		txSent("0x1", storage.TxSentStatusFailed, ""),
+	}
+
+	tests := []struct {
+		name    string
+		txsSent []*storage.TxSent
+		kind    failureKind
+		want    int
+	}{
+		{"reverted", txsSent, failureReverted, 3},
+		{"send error", txsSent, failureSendError, 1},
+		{"not found", txsSent, failureNotFound, 1},
+		{"no txs", nil, failureReverted, 0},
+		{"in flight only", txsSent[:1], failureNotFound, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countFailures(tt.txsSent, tt.kind); got != tt.want {
+				t.Fatalf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package rlr
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/service/alerts"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const securityCheckInterval = 15 * time.Second
+
+// SecurityMonitor polls admin state of bridge contract of the chain: the pause flag stops sending
+// of proposals to the contract, changes of LA proxy implementation and owner are persisted and alerted.
+// The first check is done by Run before proposals are sent
+func (r *BridgeSRV) SecurityMonitor(worker workers.IWorker) {
+	for {
+		time.Sleep(securityCheckInterval)
+		r.checkContractState(worker)
+	}
+}
+
+// checkContractState reads admin state of bridge contract, it is run for every chain before proposals are sent
+func (r *BridgeSRV) checkContractState(worker workers.IWorker) {
+	chain := worker.GetChainName()
+	state, err := worker.GetContractState()
+	if err != nil {
+		r.logger.Errorf("get contract state of %s error, err = %v", chain, err)
+		return
+	}
+
+	previous := r.storage.GetContractState(chain)
+	for _, event := range compareContractState(&previous, state) {
+		if err := r.storage.CreateSecurityEvent(event); err != nil {
+			r.logger.Errorf("save security event of %s error, err = %v", chain, err)
+		}
+		r.alerter.Raise(alerts.LevelCritical, chain, string(event.Type),
+			fmt.Sprintf("contract %s: %s -> %s", event.Contract, event.OldValue, event.NewValue))
+	}
+	r.setPaused(chain, state.Paused)
+
+	if err := r.storage.SaveContractState(state); err != nil {
+		r.logger.Errorf("save contract state of %s error, err = %v", chain, err)
+	}
+}
+
+// compareContractState returns changes of contract state which are not announced by events,
+// nothing is compared for the first state of the contract
+func compareContractState(previous, state *storage.ContractState) []*storage.SecurityEvent {
+	events := make([]*storage.SecurityEvent, 0)
+	if previous.Chain == "" || previous.Contract != state.Contract {
+		return events
+	}
+
+	changes := []struct {
+		eventType          storage.SecurityEventType
+		oldValue, newValue string
+	}{
+		{storage.SecurityEventImplementationChanged, previous.Implementation, state.Implementation},
+		{storage.SecurityEventProxyOwnerChanged, previous.ProxyOwner, state.ProxyOwner},
+	}
+	for _, change := range changes {
+		if change.oldValue == change.newValue {
+			continue
+		}
+		events = append(events, &storage.SecurityEvent{
+			Chain:    state.Chain,
+			Contract: state.Contract,
+			Type:     change.eventType,
+			OldValue: change.oldValue,
+			NewValue: change.newValue,
+		})
+	}
+	return events
+}
+
+// handleSecurityTx persists admin action taken from confirmed log of bridge or handler contract and alerts it,
+// pause of bridge contract stops sending of proposals to it at once
+func (r *BridgeSRV) handleSecurityTx(worker workers.IWorker, txLog *storage.TxLog) {
+	event, level, message := securityEventOf(txLog)
+	if event == nil {
+		return
+	}
+
+	if common.HexToAddress(event.Contract) == worker.GetConfig().ContractAddr {
+		switch event.Type {
+		case storage.SecurityEventPaused:
+			r.setPaused(txLog.Chain, true)
+		case storage.SecurityEventUnpaused:
+			r.setPaused(txLog.Chain, false)
+		}
+	}
+
+	if err := r.storage.CreateSecurityEvent(event); err != nil {
+		r.logger.Errorf("save security event of %s error, err = %v", txLog.Chain, err)
+	}
+	r.alerter.Raise(level, txLog.Chain, string(event.Type), message)
+}
+
+// securityEventOf returns admin action of the tx with level and message of its alert, nil if tx is not security one
+func securityEventOf(txLog *storage.TxLog) (*storage.SecurityEvent, alerts.Level, string) {
+	event := &storage.SecurityEvent{
+		Chain:    txLog.Chain,
+		Contract: txLog.SenderAddr,
+		NewValue: txLog.ReceiverAddr,
+		OldValue: txLog.Data,
+		TxHash:   txLog.TxHash,
+		Height:   txLog.Height,
+	}
+
+	level := alerts.LevelCritical
+	var message string
+	switch txLog.TxType {
+	case storage.TxTypePaused:
+		event.Type = storage.SecurityEventPaused
+		message = fmt.Sprintf("contract %s paused by %s, tx %s", event.Contract, event.NewValue, event.TxHash)
+	case storage.TxTypeUnpaused:
+		event.Type = storage.SecurityEventUnpaused
+		level = alerts.LevelWarning
+		message = fmt.Sprintf("contract %s unpaused by %s, tx %s", event.Contract, event.NewValue, event.TxHash)
+	case storage.TxTypeOwnershipTransferred:
+		event.Type = storage.SecurityEventOwnershipTransferred
+		// initial transfer from zero address is contract deployment
+		if common.HexToAddress(event.OldValue) == (common.Address{}) {
+			level = alerts.LevelWarning
+		}
+		message = fmt.Sprintf("ownership of contract %s transferred from %s to %s, tx %s", event.Contract, event.OldValue, event.NewValue, event.TxHash)
+	default:
+		return nil, "", ""
+	}
+	return event, level, message
+}
+
+// isPaused returns true if bridge contract of the chain is paused, or its state was never read
+func (r *BridgeSRV) isPaused(chain string) bool {
+	r.RLock()
+	defer r.RUnlock()
+	paused, known := r.paused[chain]
+	return paused || !known
+}
+
+func (r *BridgeSRV) setPaused(chain string, paused bool) {
+	r.Lock()
+	previous, known := r.paused[chain]
+	r.paused[chain] = paused
+	r.Unlock()
+
+	if known && previous == paused {
+		return
+	}
+	if paused {
+		r.logger.Warnf("bridge contract of %s is paused, sending proposals is stopped", chain)
+	} else {
+		r.logger.Infof("bridge contract of %s is unpaused, sending proposals is resumed", chain)
+	}
+}
+
+// GetSecurityEvents returns the last admin actions on contracts of the chain, all chains if chain is empty
+func (r *BridgeSRV) GetSecurityEvents(chain string, limit int) ([]*storage.SecurityEvent, error) {
+	return r.storage.GetSecurityEvents(chain, limit)
+}
@@ -0,0 +1,118 @@
+package rlr
+
+import (
+	"io"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/alerts"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/sirupsen/logrus"
+)
+
+func TestSecurityEventOf(t *testing.T) {
+	const (
+		contract = "0x00000000000000000000000000000000000000c1"
+		account  = "0x00000000000000000000000000000000000000a1"
+		zero     = "0x0000000000000000000000000000000000000000"
+	)
+	tests := []struct {
+		name      string
+		txLog     *storage.TxLog
+		wantType  storage.SecurityEventType
+		wantLevel alerts.Level
+	}{
+		{"paused", &storage.TxLog{TxType: storage.TxTypePaused, SenderAddr: contract, ReceiverAddr: account},
+			storage.SecurityEventPaused, alerts.LevelCritical},
+		{"unpaused", &storage.TxLog{TxType: storage.TxTypeUnpaused, SenderAddr: contract, ReceiverAddr: account},
+			storage.SecurityEventUnpaused, alerts.LevelWarning},
+		{"ownership transferred", &storage.TxLog{TxType: storage.TxTypeOwnershipTransferred, SenderAddr: contract, ReceiverAddr: account, Data: account},
+			storage.SecurityEventOwnershipTransferred, alerts.LevelCritical},
+		{"ownership transferred from zero address", &storage.TxLog{TxType: storage.TxTypeOwnershipTransferred, SenderAddr: contract, ReceiverAddr: account, Data: zero},
+			storage.SecurityEventOwnershipTransferred, alerts.LevelWarning},
+		{"not security tx", &storage.TxLog{TxType: storage.TxTypeDeposit, SenderAddr: account}, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.txLog.Chain, tt.txLog.TxHash, tt.txLog.Height = "LA", "0x1", 100
+			event, level, message := securityEventOf(tt.txLog)
+			if tt.wantType == "" {
+				if event != nil {
+					t.Fatalf("got event %+v, want none", event)
+				}
+				return
+			}
+			if event == nil {
+				t.Fatal("got no event")
+			}
+			if event.Type != tt.wantType || level != tt.wantLevel {
+				t.Fatalf("got %s with level %s, want %s with level %s", event.Type, level, tt.wantType, tt.wantLevel)
+			}
+			if event.Chain != "LA" || event.Contract != contract || event.NewValue != account ||
+				event.OldValue != tt.txLog.Data || event.TxHash != "0x1" || event.Height != 100 {
+				t.Fatalf("got event %+v", event)
+			}
+			if message == "" {
+				t.Fatal("got empty message")
+			}
+		})
+	}
+}
+
+func TestCompareContractState(t *testing.T) {
+	state := func(contract, implementation, proxyOwner string) *storage.ContractState {
+		return &storage.ContractState{Chain: "LA", Contract: contract, Implementation: implementation, ProxyOwner: proxyOwner}
+	}
+	tests := []struct {
+		name     string
+		previous *storage.ContractState
+		state    *storage.ContractState
+		want     []storage.SecurityEventType
+	}{
+		{"first state", &storage.ContractState{}, state("0xc", "0x1", "0xa"), nil},
+		{"not changed", state("0xc", "0x1", "0xa"), state("0xc", "0x1", "0xa"), nil},
+		{"implementation changed", state("0xc", "0x1", "0xa"), state("0xc", "0x2", "0xa"),
+			[]storage.SecurityEventType{storage.SecurityEventImplementationChanged}},
+		{"proxy owner changed", state("0xc", "0x1", "0xa"), state("0xc", "0x1", "0xb"),
+			[]storage.SecurityEventType{storage.SecurityEventProxyOwnerChanged}},
+		{"both changed", state("0xc", "0x1", "0xa"), state("0xc", "0x2", "0xb"),
+			[]storage.SecurityEventType{storage.SecurityEventImplementationChanged, storage.SecurityEventProxyOwnerChanged}},
+		{"contract replaced in config", state("0xc", "0x1", "0xa"), state("0xd", "0x2", "0xb"), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events := compareContractState(tt.previous, tt.state)
+			if len(events) != len(tt.want) {
+				t.Fatalf("got %d events, want %d", len(events), len(tt.want))
+			}
+			for i, event := range events {
+				if event.Type != tt.want[i] || event.Chain != "LA" || event.Contract != tt.state.Contract {
+					t.Fatalf("got event %+v, want %s", event, tt.want[i])
+				}
+				if event.OldValue == event.NewValue {
+					t.Fatalf("got unchanged value in event %+v", event)
+				}
+			}
+		})
+	}
+}
+
+func TestIsPaused(t *testing.T) {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	r := &BridgeSRV{logger: logger, paused: make(map[string]bool)}
+
+	if !r.isPaused("ETH") {
+		t.Fatal("chain with unknown state must be paused")
+	}
+	r.setPaused("ETH", false)
+	if r.isPaused("ETH") {
+		t.Fatal("unpaused chain is paused")
+	}
+	r.setPaused("ETH", true)
+	if !r.isPaused("ETH") {
+		t.Fatal("paused chain is not paused")
+	}
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// UnminedTxSentStatuses are statuses of sent txs which may still be mined
+var UnminedTxSentStatuses = []TxStatus{TxSentStatusInit, TxSentStatusPending, TxSentStatusNotFound}
+
+// CreateAdminAction ...
+func (d *DataBase) CreateAdminAction(action *AdminAction) error {
+	action.CreateTime = time.Now().Unix()
+	return d.db.Model(AdminAction{}).Create(action).Error
+}
+
+// GetAdminActions returns the last admin actions from the newest
+func (d *DataBase) GetAdminActions(limit int) ([]*AdminAction, error) {
+	actions := make([]*AdminAction, 0)
+	if err := d.db.Model(AdminAction{}).Order("id desc").Limit(limit).Find(&actions).Error; err != nil {
+		return nil, err
+	}
+	return actions, nil
+}
+
+// SetEventStatusByAdmin updates status of the event only if it is in one of inStatuses and records admin action
+// in the same db transaction, if noUnminedTxs is set status is not updated while any tx sent for the swap may be mined
+func (d *DataBase) SetEventStatusByAdmin(swapID string, inStatuses []EventStatus, status EventStatus, noUnminedTxs bool, action *AdminAction) error {
+	tx := d.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	if noUnminedTxs {
+		var count int64
+		if err := tx.Model(TxSent{}).Where("swap_id = ? and status in (?)", swapID, UnminedTxSentStatuses).Count(&count).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		if count != 0 {
+			tx.Rollback()
+			return fmt.Errorf("swap %s has %d txs which may still be mined", swapID, count)
+		}
+	}
+
+	query := tx.Model(Event{}).Where("swap_id = ? and status in (?)", swapID, inStatuses).Updates(
+		map[string]interface{}{
+			"status":      status,
+			"update_time": time.Now().Unix(),
+		})
+	if query.Error != nil {
+		tx.Rollback()
+		return query.Error
+	}
+	if query.RowsAffected == 0 {
+		tx.Rollback()
+		return fmt.Errorf("swap %s is not in statuses %v", swapID, inStatuses)
+	}
+
+	action.CreateTime = time.Now().Unix()
+	if err := tx.Model(AdminAction{}).Create(action).Error; err != nil {
+		tx.Rollback()
+		action.ID = 0
+		return err
+	}
+	if err := tx.Commit().Error; err != nil {
+		action.ID = 0
+		return err
+	}
+	return nil
+}
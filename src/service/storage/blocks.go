@@ -8,27 +8,22 @@ import (
 
 /*
 - CREATE - SaveBlockAndTxs
-- GET - GetCurrentBlockLog
-- UPDATE - UpdateConfirmedNum
-- DELETE - DeleteBlockAndTxs
+- GET - GetCurrentBlockLog, GetBlockLogs
+- UPDATE - UpdateConfirmedNum, ReanchorBlockLog
+- DELETE - DeleteBlockAndTxs, RewindBlockLog, ResetBlockLog
 */
 
 // SaveBlockAndTxs saves block header and block's txs(=txLogs) into database
-// Block header and height into 'block_logs'
+// Block header and height into 'block_logs', only last 'history' headers of the chain are kept
 // Txs into 'tx_logs'
 // TxLogs contains transactions with 'our' events hashes
-func (d *DataBase) SaveBlockAndTxs(chain string, blockLog *BlockLog, txLogs []*TxLog) error {
+// Logs which could not be parsed into 'quarantined_logs'
+func (d *DataBase) SaveBlockAndTxs(chain string, blockLog *BlockLog, txLogs []*TxLog, quarantinedLogs []*QuarantinedLog, history int64) error {
 	tx := d.db.Begin()
 	if err := tx.Error; err != nil {
 		return err
 	}
 
-	if err := tx.Where("chain = ? and type = ?", chain, BlockTypeParent).
-		Delete(BlockLog{}).Error; err != nil {
-		tx.Rollback()
-		return err
-	}
-
 	if err := tx.Model(BlockLog{}).Where("chain = ? and type = ?", chain, BlockTypeCurrent).Updates(
 		map[string]interface{}{
 			"type": BlockTypeParent,
@@ -50,23 +45,153 @@ func (d *DataBase) SaveBlockAndTxs(chain string, blockLog *BlockLog, txLogs []*T
 		}
 	}
 
+	for _, quarantinedLog := range quarantinedLogs {
+		quarantinedLog.Status = QuarantineStatusQuarantined
+		quarantinedLog.CreateTime = time.Now().Unix()
+		quarantinedLog.UpdateTime = quarantinedLog.CreateTime
+		if err := tx.Create(quarantinedLog).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := pruneBlockLogs(tx, chain, history); err != nil {
+		tx.Rollback()
+		return err
+	}
+
 	return tx.Commit().Error
 }
 
-// DeleteBlockAndTxs deletes from 'block_logs' and 'tx_logs' block and txs with
-// current chain and height of block
+// pruneBlockLogs deletes headers of the chain older than last 'history' ones
+func pruneBlockLogs(tx *gorm.DB, chain string, history int64) error {
+	if history <= 0 {
+		return nil
+	}
+
+	var oldest BlockLog
+	if err := tx.Where("chain = ?", chain).Order("height desc").Offset(history - 1).First(&oldest).Error; err != nil {
+		if gorm.IsRecordNotFoundError(err) {
+			return nil
+		}
+		return err
+	}
+
+	return tx.Where("chain = ? and height < ?", chain, oldest.Height).Delete(BlockLog{}).Error
+}
+
+// DeleteBlockAndTxs deletes from 'block_logs' and 'tx_logs' blocks and not yet confirmed txs
+// of current chain above height of block, block at height becomes current one
+// Not yet released logs above height are deleted from 'quarantined_logs' too
 func (d *DataBase) DeleteBlockAndTxs(chain string, height int64) error {
 	tx := d.db.Begin()
 	if err := tx.Error; err != nil {
 		return err
 	}
 
-	if err := tx.Where("height = ? and chain = ?", height, chain).Delete(BlockLog{}).Error; err != nil {
+	if err := deleteBlocksAbove(tx, chain, height); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// RewindBlockLog makes the highest stored block at or below height current one, blocks, not yet confirmed txs
+// and quarantined logs above it are deleted, so they are scanned again. Returns false if there is no such block
+func (d *DataBase) RewindBlockLog(chain string, height int64) (bool, error) {
+	tx := d.db.Begin()
+	if err := tx.Error; err != nil {
+		return false, err
+	}
+
+	var blockLog BlockLog
+	query := tx.Where("chain = ? and height <= ?", chain, height).Order("height desc").First(&blockLog)
+	if query.RecordNotFound() {
+		tx.Rollback()
+		return false, nil
+	}
+	if query.Error != nil {
+		tx.Rollback()
+		return false, query.Error
+	}
+
+	if err := deleteBlocksAbove(tx, chain, blockLog.Height); err != nil {
+		tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit().Error
+}
+
+func deleteBlocksAbove(tx *gorm.DB, chain string, height int64) error {
+	if err := tx.Where("height > ? and chain = ?", height, chain).Delete(BlockLog{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("height > ? and chain = ? and status = ?", height, chain, TxStatusInit).Delete(TxLog{}).Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("height > ? and chain = ? and status = ?", height, chain, QuarantineStatusQuarantined).Delete(QuarantinedLog{}).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(BlockLog{}).Where("height = ? and chain = ?", height, chain).Updates(
+		map[string]interface{}{
+			"type": BlockTypeCurrent,
+		}).Error
+}
+
+// ResetBlockLog replaces stored headers of the chain with block as the only current one, so watcher
+// continues from its height, not yet confirmed txs and quarantined logs above it are deleted
+func (d *DataBase) ResetBlockLog(blockLog *BlockLog) error {
+	tx := d.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	if err := tx.Where("chain = ?", blockLog.Chain).Delete(BlockLog{}).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
 
-	if err := tx.Where("height = ? and chain = ? and status = ?", height, chain, TxStatusInit).Delete(TxLog{}).Error; err != nil {
+	if err := tx.Where("height > ? and chain = ? and status = ?", blockLog.Height, blockLog.Chain, TxStatusInit).Delete(TxLog{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Where("height > ? and chain = ? and status = ?", blockLog.Height, blockLog.Chain, QuarantineStatusQuarantined).Delete(QuarantinedLog{}).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	blockLog.Type = BlockTypeCurrent
+	if err := tx.Create(blockLog).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit().Error
+}
+
+// ReanchorBlockLog deletes blocks and not yet confirmed txs above height of block
+// and replaces hash of block at height with the canonical one
+func (d *DataBase) ReanchorBlockLog(chain string, height int64, blockHash string) error {
+	tx := d.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	if err := deleteBlocksAbove(tx, chain, height); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Model(BlockLog{}).Where("height = ? and chain = ?", height, chain).Updates(
+		map[string]interface{}{
+			"block_hash": blockHash,
+		}).Error; err != nil {
 		tx.Rollback()
 		return err
 	}
@@ -88,3 +213,9 @@ func (d *DataBase) GetCurrentBlockLog(chainID string) (logs BlockLog) {
 	d.db.Where("chain = ?", chainID).Order("height desc").First(&logs)
 	return logs
 }
+
+// GetBlockLogs returns stored block's logs of the chain from the newest to the oldest
+func (d *DataBase) GetBlockLogs(chainID string) (logs []*BlockLog) {
+	d.db.Where("chain = ?", chainID).Order("height desc").Find(&logs)
+	return logs
+}
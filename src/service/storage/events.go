@@ -1,6 +1,7 @@
 package storage
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/jinzhu/gorm"
@@ -19,6 +20,38 @@ func (d *DataBase) getEventBySwapID(swapID string) (event Event, err error) {
 	return event, nil
 }
 
+// GetEventBySwapID ...
+func (d *DataBase) GetEventBySwapID(swapID string) (*Event, error) {
+	event, err := d.getEventBySwapID(swapID)
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+// GetEventByDepositTxHash returns event of the swap by hash of its deposit tx
+func (d *DataBase) GetEventByDepositTxHash(txHash string) (*Event, error) {
+	txLog := &TxLog{}
+	if err := d.db.Model(TxLog{}).Where("tx_hash = ? and tx_type = ?", txHash, TxTypeDeposit).
+		First(&txLog).Error; err != nil {
+		return nil, err
+	}
+
+	return d.GetEventBySwapID(txLog.SwapID)
+}
+
+// GetEventsByDeposit returns events by origin chain, deposit nonce and resource id,
+// it is used to link txs which don't carry destination chain to the swap
+func (d *DataBase) GetEventsByDeposit(originChainID string, depositNonce uint64, resourceID string) ([]*Event, error) {
+	events := make([]*Event, 0)
+	if err := d.db.Where("origin_chain_id = ? and deposit_nonce = ? and resource_id = ?",
+		originChainID, depositNonce, resourceID).Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
 // GetEventsByTypeAndStatuses ...
 func (d *DataBase) GetEventsByTypeAndStatuses(statuses []EventStatus) []*Event {
 	swaps := make([]*Event, 0)
@@ -30,6 +63,34 @@ func (d *DataBase) GetEventsByTypeAndStatuses(statuses []EventStatus) []*Event {
 	return swaps
 }
 
+// GetEventsCreatedBefore returns events in statuses created before the time(unix seconds) from the oldest
+func (d *DataBase) GetEventsCreatedBefore(statuses []EventStatus, before int64) ([]*Event, error) {
+	events := make([]*Event, 0)
+	if err := d.db.Where("status in (?) and create_time < ?", statuses, before).
+		Order("create_time asc").Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// CountEventsByStatus returns number of events in every status
+func (d *DataBase) CountEventsByStatus() (map[EventStatus]int64, error) {
+	var rows []struct {
+		Status EventStatus
+		Count  int64
+	}
+	if err := d.db.Model(Event{}).Select("status, count(*) as count").Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[EventStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 // UpdateEventStatus ...
 func (d *DataBase) UpdateEventStatus(event *Event, status EventStatus) {
 	event.Status = status
@@ -38,6 +99,22 @@ func (d *DataBase) UpdateEventStatus(event *Event, status EventStatus) {
 	d.db.Model(Event{}).Where("swap_id = ?", event.SwapID).Update(event)
 }
 
+// SetEventStatus updates status of the event only if it is in one of inStatuses
+func (d *DataBase) SetEventStatus(swapID string, inStatuses []EventStatus, status EventStatus) error {
+	query := d.db.Model(Event{}).Where("swap_id = ? and status in (?)", swapID, inStatuses).Updates(
+		map[string]interface{}{
+			"status":      status,
+			"update_time": time.Now().Unix(),
+		})
+	if query.Error != nil {
+		return query.Error
+	}
+	if query.RowsAffected == 0 {
+		return fmt.Errorf("swap %s is not in statuses %v", swapID, inStatuses)
+	}
+	return nil
+}
+
 // CompensateNewEvent ...
 func (d *DataBase) CompensateNewEvent(chain string, tx *gorm.DB, newEvents []*Event) error {
 	for _, event := range newEvents {
@@ -104,3 +181,74 @@ func (d *DataBase) UpdateParticularEventStatus(event Event, status EventStatus,
 	event.Status = status
 	return query.Updates(event).Error
 }
+
+// EventFilter selects events, empty fields are not filtered
+// Address matches either sender or receiver, events after(before if Desc) Cursor are returned
+type EventFilter struct {
+	SenderAddr         string
+	ReceiverAddr       string
+	Address            string
+	OriginChainID      string
+	DestinationChainID string
+	ResourceID         string
+	Statuses           []EventStatus
+	From               int64
+	To                 int64
+	Cursor             *EventCursor
+	Desc               bool
+	Limit              int
+}
+
+// EventCursor is position of event in list ordered by create time and swap id
+type EventCursor struct {
+	CreateTime int64
+	SwapID     string
+}
+
+// FindEvents returns events by filter ordered by create time and swap id
+func (d *DataBase) FindEvents(filter *EventFilter) ([]*Event, error) {
+	query := d.db.Model(Event{})
+	if filter.SenderAddr != "" {
+		query = query.Where("sender_addr = ?", filter.SenderAddr)
+	}
+	if filter.ReceiverAddr != "" {
+		query = query.Where("receiver_addr = ?", filter.ReceiverAddr)
+	}
+	if filter.Address != "" {
+		query = query.Where("sender_addr = ? or receiver_addr = ?", filter.Address, filter.Address)
+	}
+	if filter.OriginChainID != "" {
+		query = query.Where("origin_chain_id = ?", filter.OriginChainID)
+	}
+	if filter.DestinationChainID != "" {
+		query = query.Where("destination_chain_id = ?", filter.DestinationChainID)
+	}
+	if filter.ResourceID != "" {
+		query = query.Where("resource_id = ?", filter.ResourceID)
+	}
+	if len(filter.Statuses) != 0 {
+		query = query.Where("status in (?)", filter.Statuses)
+	}
+	if filter.From != 0 {
+		query = query.Where("create_time >= ?", filter.From)
+	}
+	if filter.To != 0 {
+		query = query.Where("create_time < ?", filter.To)
+	}
+
+	order := "create_time asc, swap_id asc"
+	if filter.Desc {
+		order = "create_time desc, swap_id desc"
+		if filter.Cursor != nil {
+			query = query.Where("(create_time, swap_id) < (?, ?)", filter.Cursor.CreateTime, filter.Cursor.SwapID)
+		}
+	} else if filter.Cursor != nil {
+		query = query.Where("(create_time, swap_id) > (?, ?)", filter.Cursor.CreateTime, filter.Cursor.SwapID)
+	}
+
+	events := make([]*Event, 0)
+	if err := query.Order(order).Limit(filter.Limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
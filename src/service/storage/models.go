@@ -2,10 +2,10 @@ package storage
 
 // BlockLog ...
 type BlockLog struct {
-	Chain      string    `gorm:"type:TEXT"`
+	Chain      string    `gorm:"type:TEXT;index:idx_block_logs_chain_height"`
 	BlockHash  string    `gorm:"type:TEXT"`
 	ParentHash string    `gorm:"type:TEXT"`
-	Height     int64     `gorm:"type:BIGINT"`
+	Height     int64     `gorm:"type:BIGINT;index:idx_block_logs_chain_height"`
 	BlockTime  int64     `gorm:"type:BIGINT"`
 	Type       BlockType `gorm:"block_type"`
 	CreateTime int64     `gorm:"type:BIGINT"`
@@ -13,31 +13,31 @@ type BlockLog struct {
 
 // TxLog ...
 type TxLog struct {
-	Chain              string `gorm:"type:TEXT"`
-	EventID            string
-	TxType             TxType      `gorm:"type:tx_types"`
-	TxHash             string      `gorm:"type:TEXT"`
-	SenderAddr         string      `gorm:"type:TEXT"`
-	Data               string      `gorm:"type:TEXT"`
-	DestinationChainID string      `gorm:"type:TEXT"`
-	ExpireHeight       int64       `gorm:"type:BIGINT"`
-	Timestamp          int64       `gorm:"type:BIGINT"`
-	BlockHash          string      `gorm:"type:TEXT"`
-	Height             int64       `gorm:"type:BIGINT"`
-	Status             TxLogStatus `gorm:"type:tx_log_statuses"`
-	EventStatus        EventStatus
-	ConfirmedNum       int64  `gorm:"type:BIGINT"`
-	CreateTime         int64  `gorm:"type:BIGINT"`
-	UpdateTime         int64  `gorm:"type:BIGINT"`
-	SwapID             string `gorm:"primaryKey"`
-	OriginChainID      string `gorm:"type:TEXT"`
-	DepositNonce       uint64 `gorm:"type:BIGINT"`
-	SwapStatus         uint8
-	ResourceID         string `gorm:"type:TEXT"`
-	ReceiverAddr       string `gorm:"type:TEXT"`
-	WorkerChainAddr    string `gorm:"type:TEXT"`
-	OutAmount          string `gorm:"type:TEXT"`
-	InAmount           string `gorm:"type:TEXT"`
+	Chain              string      `json:"chain" gorm:"type:TEXT"`
+	EventID            string      `json:"event_id"`
+	TxType             TxType      `json:"tx_type" gorm:"type:tx_types"`
+	TxHash             string      `json:"tx_hash" gorm:"type:TEXT"`
+	SenderAddr         string      `json:"sender_addr" gorm:"type:TEXT"`
+	Data               string      `json:"data" gorm:"type:TEXT"`
+	DestinationChainID string      `json:"destination_chain_id" gorm:"type:TEXT"`
+	ExpireHeight       int64       `json:"expire_height" gorm:"type:BIGINT"`
+	Timestamp          int64       `json:"timestamp" gorm:"type:BIGINT"`
+	BlockHash          string      `json:"block_hash" gorm:"type:TEXT"`
+	Height             int64       `json:"height" gorm:"type:BIGINT"`
+	Status             TxLogStatus `json:"status" gorm:"type:tx_log_statuses"`
+	EventStatus        EventStatus `json:"event_status"`
+	ConfirmedNum       int64       `json:"confirmed_num" gorm:"type:BIGINT"`
+	CreateTime         int64       `json:"create_time" gorm:"type:BIGINT"`
+	UpdateTime         int64       `json:"update_time" gorm:"type:BIGINT"`
+	SwapID             string      `json:"swap_id" gorm:"primaryKey"`
+	OriginChainID      string      `json:"origin_chain_id" gorm:"type:TEXT"`
+	DepositNonce       uint64      `json:"deposit_nonce" gorm:"type:BIGINT"`
+	SwapStatus         uint8       `json:"swap_status"`
+	ResourceID         string      `json:"resource_id" gorm:"type:TEXT"`
+	ReceiverAddr       string      `json:"receiver_addr" gorm:"type:TEXT"`
+	WorkerChainAddr    string      `json:"worker_chain_addr" gorm:"type:TEXT"`
+	OutAmount          string      `json:"out_amount" gorm:"type:TEXT"`
+	InAmount           string      `json:"in_amount" gorm:"type:TEXT"`
 }
 
 // Registration
@@ -50,36 +50,38 @@ type Registration struct {
 
 // Event ...
 type Event struct {
-	SwapID             string `gorm:"primaryKey"`
-	ChainID            string
-	DestinationChainID string
-	OriginChainID      string
-	SenderAddr         string
-	ReceiverAddr       string
-	InTokenAddr        string
-	OutTokenAddr       string
-	InAmount           string
-	OutAmount          string
-	Height             int64
-	Status             EventStatus
-	CreateTime         int64
-	UpdateTime         int64
-	DepositNonce       uint64
-	ResourceID         string
-	TxType             string
+	SwapID             string      `json:"swap_id" gorm:"primaryKey"`
+	ChainID            string      `json:"chain_id"`
+	DestinationChainID string      `json:"destination_chain_id" gorm:"index:idx_events_destination_chain_id"`
+	OriginChainID      string      `json:"origin_chain_id" gorm:"index:idx_events_origin_chain_id"`
+	SenderAddr         string      `json:"sender_addr" gorm:"index:idx_events_sender_addr"`
+	ReceiverAddr       string      `json:"receiver_addr" gorm:"index:idx_events_receiver_addr"`
+	InTokenAddr        string      `json:"in_token_addr"`
+	OutTokenAddr       string      `json:"out_token_addr"`
+	InAmount           string      `json:"in_amount"`
+	OutAmount          string      `json:"out_amount"`
+	Height             int64       `json:"height"`
+	Status             EventStatus `json:"status" gorm:"index:idx_events_status"`
+	CreateTime         int64       `json:"create_time"`
+	UpdateTime         int64       `json:"update_time"`
+	DepositNonce       uint64      `json:"deposit_nonce"`
+	ResourceID         string      `json:"resource_id" gorm:"index:idx_events_resource_id"`
+	TxType             string      `json:"tx_type"`
 }
 
 // TxSent ...
+// ReplacedTxHash is hash of the stuck tx with the same nonce replaced by this one
 type TxSent struct {
-	ID         int64    `json:"id"`
-	Chain      string   `json:"chain" gorm:"type:TEXT"`
-	SwapID     string   `json:"swap_id" gorm:"type:TEXT"`
-	Type       TxType   `json:"type" gorm:"type:tx_types"`
-	TxHash     string   `json:"tx_hash" gorm:"type:TEXT"`
-	ErrMsg     string   `json:"err_msg" gorm:"type:TEXT"`
-	Status     TxStatus `json:"status" gorm:"type:tx_statuses"`
-	CreateTime int64    `json:"create_time" gorm:"type:BIGINT"`
-	UpdateTime int64    `json:"update_time" gorm:"type:BIGINT"`
+	ID             int64    `json:"id"`
+	Chain          string   `json:"chain" gorm:"type:TEXT"`
+	SwapID         string   `json:"swap_id" gorm:"type:TEXT"`
+	Type           TxType   `json:"type" gorm:"type:tx_types"`
+	TxHash         string   `json:"tx_hash" gorm:"type:TEXT"`
+	ReplacedTxHash string   `json:"replaced_tx_hash" gorm:"type:TEXT"`
+	ErrMsg         string   `json:"err_msg" gorm:"type:TEXT"`
+	Status         TxStatus `json:"status" gorm:"type:tx_statuses"`
+	CreateTime     int64    `json:"create_time" gorm:"type:BIGINT"`
+	UpdateTime     int64    `json:"update_time" gorm:"type:BIGINT"`
 }
 
 // GasPrice
@@ -89,6 +91,87 @@ type GasPrice struct {
 	UpdateTime int64  `json:"update_time" gorm:"type:BIGINT"`
 }
 
+// Nonce is the last nonce used by the worker on the chain
+type Nonce struct {
+	Chain      string `gorm:"primaryKey"`
+	Address    string `gorm:"type:TEXT"`
+	Nonce      uint64 `gorm:"type:BIGINT"`
+	UpdateTime int64  `gorm:"type:BIGINT"`
+}
+
+// Setting is runtime setting of relayer shared by all its instances, like operating mode
+type Setting struct {
+	Name       string `gorm:"primaryKey"`
+	Value      string `gorm:"type:TEXT"`
+	UpdateTime int64  `gorm:"type:BIGINT"`
+}
+
+// SignedTx is raw tx signed by the worker, kept to replace the tx with the same nonce even after nodes dropped it
+type SignedTx struct {
+	ID         int64
+	Chain      string `gorm:"type:TEXT"`
+	TxHash     string `gorm:"type:TEXT;unique_index"`
+	Nonce      uint64 `gorm:"type:BIGINT"`
+	RawTx      string `gorm:"type:TEXT"`
+	CreateTime int64  `gorm:"type:BIGINT"`
+}
+
+// SecurityEvent is admin action on bridge or handler contract, taken from its log or noticed in its state
+type SecurityEvent struct {
+	ID         int64             `json:"id"`
+	Chain      string            `json:"chain" gorm:"type:TEXT"`
+	Contract   string            `json:"contract" gorm:"type:TEXT"`
+	Type       SecurityEventType `json:"type" gorm:"type:TEXT"`
+	OldValue   string            `json:"old_value" gorm:"type:TEXT"`
+	NewValue   string            `json:"new_value" gorm:"type:TEXT"`
+	TxHash     string            `json:"tx_hash" gorm:"type:TEXT"`
+	Height     int64             `json:"height" gorm:"type:BIGINT"`
+	CreateTime int64             `json:"create_time" gorm:"type:BIGINT"`
+}
+
+// ContractState is the last known admin state of bridge contract of the chain
+type ContractState struct {
+	Chain          string `json:"chain" gorm:"primaryKey"`
+	Contract       string `json:"contract" gorm:"type:TEXT"`
+	Paused         bool   `json:"paused"`
+	Owner          string `json:"owner" gorm:"type:TEXT"`
+	Implementation string `json:"implementation" gorm:"type:TEXT"`
+	ProxyOwner     string `json:"proxy_owner" gorm:"type:TEXT"`
+	UpdateTime     int64  `json:"update_time" gorm:"type:BIGINT"`
+}
+
+// QuarantinedLog is bridge contract log which failed parsing or has unknown event id(topic0),
+// it is kept raw to be re-parsed after the fix
+// Topics are hex encoded and comma separated
+type QuarantinedLog struct {
+	ID         int64            `json:"id"`
+	Chain      string           `json:"chain" gorm:"type:TEXT;index:idx_quarantined_logs_chain_height"`
+	Contract   string           `json:"contract" gorm:"type:TEXT"`
+	TxHash     string           `json:"tx_hash" gorm:"type:TEXT"`
+	LogIndex   uint             `json:"log_index" gorm:"type:BIGINT"`
+	BlockHash  string           `json:"block_hash" gorm:"type:TEXT"`
+	Height     int64            `json:"height" gorm:"type:BIGINT;index:idx_quarantined_logs_chain_height"`
+	Topics     string           `json:"topics" gorm:"type:TEXT"`
+	Data       string           `json:"data" gorm:"type:TEXT"`
+	Error      string           `json:"error" gorm:"type:TEXT"`
+	Status     QuarantineStatus `json:"status" gorm:"type:TEXT"`
+	CreateTime int64            `json:"create_time" gorm:"type:BIGINT"`
+	UpdateTime int64            `json:"update_time" gorm:"type:BIGINT"`
+}
+
+// AdminAction is manual operation made via admin api, kept for audit
+type AdminAction struct {
+	ID         int64  `json:"id"`
+	Action     string `json:"action" gorm:"type:TEXT"`
+	Target     string `json:"target" gorm:"type:TEXT"`
+	Params     string `json:"params" gorm:"type:TEXT"`
+	Reason     string `json:"reason" gorm:"type:TEXT"`
+	Actor      string `json:"actor" gorm:"type:TEXT"`
+	Result     string `json:"result" gorm:"type:TEXT"`
+	Error      string `json:"error" gorm:"type:TEXT"`
+	CreateTime int64  `json:"create_time" gorm:"type:BIGINT"`
+}
+
 type ResourceId struct {
 	Name string `gorm:"primaryKey"`
 	ID   string `gorm:"type:TEXT"`
@@ -0,0 +1,24 @@
+package storage
+
+import "time"
+
+// GetNonce returns last used nonce of the worker on the chain
+func (d *DataBase) GetNonce(chain string) (nonce Nonce) {
+	d.db.Model(Nonce{}).Where("chain = ?", chain).First(&nonce)
+	return nonce
+}
+
+// SaveNonce saves last used nonce of the worker on the chain
+func (d *DataBase) SaveNonce(nonce *Nonce) error {
+	nonce.UpdateTime = time.Now().Unix()
+	if previous := d.GetNonce(nonce.Chain); previous.Chain == "" {
+		return d.db.Model(Nonce{}).Create(nonce).Error
+	}
+
+	return d.db.Model(Nonce{}).Where("chain = ?", nonce.Chain).Updates(
+		map[string]interface{}{
+			"address":     nonce.Address,
+			"nonce":       nonce.Nonce,
+			"update_time": nonce.UpdateTime,
+		}).Error
+}
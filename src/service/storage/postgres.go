@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"fmt"
+
 	"github.com/jinzhu/gorm"
 )
 
@@ -21,11 +23,19 @@ func InitStorage(db *gorm.DB) (*DataBase, error) {
 	if err := db.Exec(createTxTypeIfNotExists).Error; err != nil {
 		return nil, err
 	}
+	for _, txType := range auditTxTypes {
+		if err := db.Exec(fmt.Sprintf(addTxTypeIfNotExists, txType)).Error; err != nil {
+			return nil, err
+		}
+	}
 
 	// 'tx_statuses'
 	if err := db.Exec(createTxStatusIfNotExists).Error; err != nil {
 		return nil, err
 	}
+	if err := db.Exec(fmt.Sprintf(addTxStatusIfNotExists, TxSentStatusReverted)).Error; err != nil {
+		return nil, err
+	}
 
 	// migrate table "block_log"
 	if err := db.AutoMigrate(BlockLog{}).Error; err != nil {
@@ -41,6 +51,14 @@ func InitStorage(db *gorm.DB) (*DataBase, error) {
 	if err := db.AutoMigrate(Event{}).Error; err != nil {
 		return nil, err
 	}
+	// swaps are listed by create time, swap id makes order unique for pagination cursor
+	if err := db.Model(Event{}).AddIndex("idx_events_create_time_swap_id", "create_time", "swap_id").Error; err != nil {
+		return nil, err
+	}
+	// notify status changes of events
+	if err := db.Exec(createEventStatusNotify).Error; err != nil {
+		return nil, err
+	}
 
 	// migrate table "tx_sent"
 	if err := db.AutoMigrate(TxSent{}).Error; err != nil {
@@ -57,9 +75,49 @@ func InitStorage(db *gorm.DB) (*DataBase, error) {
 		return nil, err
 	}
 
+	// migrate table "nonces"
+	if err := db.AutoMigrate(Nonce{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "signed_txes"
+	if err := db.AutoMigrate(SignedTx{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "security_events"
+	if err := db.AutoMigrate(SecurityEvent{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "contract_states"
+	if err := db.AutoMigrate(ContractState{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "quarantined_logs"
+	if err := db.AutoMigrate(QuarantinedLog{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "settings"
+	if err := db.AutoMigrate(Setting{}).Error; err != nil {
+		return nil, err
+	}
+
+	// migrate table "admin_actions"
+	if err := db.AutoMigrate(AdminAction{}).Error; err != nil {
+		return nil, err
+	}
+
 	return &DataBase{db: db}, nil
 }
 
+// Ping checks connection to database
+func (d *DataBase) Ping() error {
+	return d.db.DB().Ping()
+}
+
 // // ExpireUserHTLT ...
 // func (d *DataBase) ExpireUserHTLT(chainID string) error {
 // 	curBlock, err := d.GetCurrentBlockLog(chainID)
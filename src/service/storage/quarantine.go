@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+)
+
+// GetQuarantinedLogs returns logs of the chain with status from the oldest, all chains if chain is empty
+func (d *DataBase) GetQuarantinedLogs(chain string, status QuarantineStatus, limit int) ([]*QuarantinedLog, error) {
+	logs := make([]*QuarantinedLog, 0)
+	query := d.db.Model(QuarantinedLog{}).Where("status = ?", status)
+	if chain != "" {
+		query = query.Where("chain = ?", chain)
+	}
+	if err := query.Order("id asc").Limit(limit).Find(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GetQuarantinedLog ...
+func (d *DataBase) GetQuarantinedLog(id int64) (*QuarantinedLog, error) {
+	var log QuarantinedLog
+	if err := d.db.Model(QuarantinedLog{}).Where("id = ?", id).First(&log).Error; err != nil {
+		return nil, err
+	}
+	return &log, nil
+}
+
+// UpdateQuarantinedLogError keeps error of the last failed re-parse
+func (d *DataBase) UpdateQuarantinedLogError(id int64, errMsg string) error {
+	return d.db.Model(QuarantinedLog{}).Where("id = ?", id).Updates(
+		map[string]interface{}{
+			"error":       errMsg,
+			"update_time": time.Now().Unix(),
+		}).Error
+}
+
+// ReleaseQuarantinedLog puts re-parsed log into 'tx_logs', it is confirmed then as any other tx of the chain,
+// log released already by concurrent re-parse is not inserted twice
+func (d *DataBase) ReleaseQuarantinedLog(id int64, txLog *TxLog) error {
+	tx := d.db.Begin()
+	if err := tx.Error; err != nil {
+		return err
+	}
+
+	query := tx.Model(QuarantinedLog{}).Where("id = ? and status = ?", id, QuarantineStatusQuarantined).Updates(
+		map[string]interface{}{
+			"status":      QuarantineStatusReleased,
+			"error":       "",
+			"update_time": time.Now().Unix(),
+		})
+	if query.Error != nil {
+		tx.Rollback()
+		return query.Error
+	}
+	if query.RowsAffected != 1 {
+		tx.Rollback()
+		return fmt.Errorf("quarantined log %d is released already", id)
+	}
+
+	if txLog != nil {
+		txLog.CreateTime = time.Now().Unix()
+		if err := tx.Create(txLog).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit().Error
+}
@@ -41,3 +41,9 @@ func (d *DataBase) FetchResourceIDByName(name string) (rID ResourceId) {
 	d.db.Model(ResourceId{}).Where("name = ?", name).First(&rID)
 	return rID
 }
+
+// FetchResourceIDs returns all known resource ids
+func (d *DataBase) FetchResourceIDs() (rIDs []*ResourceId) {
+	d.db.Model(ResourceId{}).Find(&rIDs)
+	return rIDs
+}
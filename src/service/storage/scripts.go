@@ -11,6 +11,10 @@ var (
         END$$;
     `
 
+	// values added to 'tx_types' after it was created, ADD VALUE can't run in transaction block,
+	// so every value is added by its own statement
+	addTxTypeIfNotExists = `ALTER TYPE tx_types ADD VALUE IF NOT EXISTS '%s'`
+
 	createTxLogStatusIfNotExists = `
         DO $$
         BEGIN
@@ -31,6 +35,9 @@ var (
         END$$;
     `
 
+	// values added to 'tx_statuses' after it was created
+	addTxStatusIfNotExists = `ALTER TYPE tx_statuses ADD VALUE IF NOT EXISTS '%s'`
+
 	// sql script for 'block_type'
 	createTypeIfNotExistsBlockTypeStatus = `
         DO $$
@@ -41,4 +48,30 @@ var (
             END IF;
         END$$;
     `
+
+	// status changes of 'events' are published into 'event_status' channel,
+	// so every instance of the service can push them to its clients
+	createEventStatusNotify = `
+        CREATE OR REPLACE FUNCTION notify_event_status() RETURNS trigger AS $$
+        BEGIN
+            IF TG_OP = 'UPDATE' AND OLD.status IS NOT DISTINCT FROM NEW.status THEN
+                RETURN NEW;
+            END IF;
+            PERFORM pg_notify('` + EventStatusChannel + `', json_build_object(
+                'swap_id', NEW.swap_id,
+                'status', NEW.status,
+                'sender_addr', NEW.sender_addr,
+                'receiver_addr', NEW.receiver_addr,
+                'origin_chain_id', NEW.origin_chain_id,
+                'destination_chain_id', NEW.destination_chain_id,
+                'update_time', NEW.update_time
+            )::text);
+            RETURN NEW;
+        END;
+        $$ LANGUAGE plpgsql;
+
+        DROP TRIGGER IF EXISTS events_status_notify ON events;
+        CREATE TRIGGER events_status_notify AFTER INSERT OR UPDATE ON events
+            FOR EACH ROW EXECUTE PROCEDURE notify_event_status();
+    `
 )
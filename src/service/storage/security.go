@@ -0,0 +1,46 @@
+package storage
+
+import "time"
+
+// CreateSecurityEvent ...
+func (d *DataBase) CreateSecurityEvent(event *SecurityEvent) error {
+	event.CreateTime = time.Now().Unix()
+	return d.db.Model(SecurityEvent{}).Create(event).Error
+}
+
+// GetSecurityEvents returns admin actions on contracts of the chain from the newest, all chains if chain is empty
+func (d *DataBase) GetSecurityEvents(chain string, limit int) ([]*SecurityEvent, error) {
+	events := make([]*SecurityEvent, 0)
+	query := d.db.Model(SecurityEvent{})
+	if chain != "" {
+		query = query.Where("chain = ?", chain)
+	}
+	if err := query.Order("id desc").Limit(limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+// GetContractState returns the last known state of bridge contract of the chain
+func (d *DataBase) GetContractState(chain string) (state ContractState) {
+	d.db.Model(ContractState{}).Where("chain = ?", chain).First(&state)
+	return state
+}
+
+// SaveContractState ...
+func (d *DataBase) SaveContractState(state *ContractState) error {
+	state.UpdateTime = time.Now().Unix()
+	if previous := d.GetContractState(state.Chain); previous.Chain == "" {
+		return d.db.Model(ContractState{}).Create(state).Error
+	}
+
+	return d.db.Model(ContractState{}).Where("chain = ?", state.Chain).Updates(
+		map[string]interface{}{
+			"contract":       state.Contract,
+			"paused":         state.Paused,
+			"owner":          state.Owner,
+			"implementation": state.Implementation,
+			"proxy_owner":    state.ProxyOwner,
+			"update_time":    state.UpdateTime,
+		}).Error
+}
@@ -0,0 +1,30 @@
+package storage
+
+import "time"
+
+// SettingMode is name of operating mode setting
+const SettingMode = "mode"
+
+// GetSetting returns value of the setting, empty if it is not saved yet
+func (d *DataBase) GetSetting(name string) (string, error) {
+	var setting Setting
+	query := d.db.Model(Setting{}).Where("name = ?", name).First(&setting)
+	if query.RecordNotFound() {
+		return "", nil
+	}
+	return setting.Value, query.Error
+}
+
+// SaveSetting ...
+func (d *DataBase) SaveSetting(name, value string) error {
+	setting := &Setting{Name: name, Value: value, UpdateTime: time.Now().Unix()}
+	query := d.db.Model(Setting{}).Where("name = ?", name).Updates(
+		map[string]interface{}{
+			"value":       setting.Value,
+			"update_time": setting.UpdateTime,
+		})
+	if query.Error != nil || query.RowsAffected != 0 {
+		return query.Error
+	}
+	return d.db.Model(Setting{}).Create(setting).Error
+}
@@ -0,0 +1,18 @@
+package storage
+
+import "time"
+
+// SaveSignedTx keeps raw tx signed by the worker
+func (d *DataBase) SaveSignedTx(tx *SignedTx) error {
+	tx.CreateTime = time.Now().Unix()
+	return d.db.Model(SignedTx{}).Create(tx).Error
+}
+
+// GetSignedTx returns raw tx by hash
+func (d *DataBase) GetSignedTx(chain, txHash string) (*SignedTx, error) {
+	tx := &SignedTx{}
+	if err := d.db.Model(SignedTx{}).Where("chain = ? and tx_hash = ?", chain, txHash).First(tx).Error; err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
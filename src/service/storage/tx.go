@@ -25,6 +25,46 @@ func (d *DataBase) FindTxLogs(chainID string, confirmNum int64) (txLogs []*TxLog
 	return txLogs, nil
 }
 
+// GetTxLogsBySwapID returns txs of the swap seen on chains from the earliest
+func (d *DataBase) GetTxLogsBySwapID(swapID string) ([]*TxLog, error) {
+	txLogs := make([]*TxLog, 0)
+	if err := d.db.Where("swap_id = ?", swapID).Order("create_time asc").Find(&txLogs).Error; err != nil {
+		return nil, err
+	}
+	return txLogs, nil
+}
+
+// GetTxsSentBySwapID returns every tx sent by relayer for the swap from the earliest
+func (d *DataBase) GetTxsSentBySwapID(swapID string) ([]*TxSent, error) {
+	txsSent := make([]*TxSent, 0)
+	if err := d.db.Where("swap_id = ?", swapID).Order("id asc").Find(&txsSent).Error; err != nil {
+		return nil, err
+	}
+	return txsSent, nil
+}
+
+// GetUnlinkedTxLogs returns confirmed txs of the type created after the time which are not linked to any swap yet
+// from the oldest
+func (d *DataBase) GetUnlinkedTxLogs(chain string, txType TxType, createdAfter int64, limit int) ([]*TxLog, error) {
+	txLogs := make([]*TxLog, 0)
+	if err := d.db.Where("chain = ? and tx_type = ? and status = ? and swap_id = ? and create_time > ?",
+		chain, txType, TxStatusConfirmed, "", createdAfter).
+		Order("height asc").Limit(limit).Find(&txLogs).Error; err != nil {
+		return nil, err
+	}
+	return txLogs, nil
+}
+
+// UpdateTxLogSwap links tx to the swap
+func (d *DataBase) UpdateTxLogSwap(txLog *TxLog) error {
+	return d.db.Model(TxLog{}).Where("chain = ? and tx_hash = ? and tx_type = ?", txLog.Chain, txLog.TxHash, txLog.TxType).Updates(
+		map[string]interface{}{
+			"swap_id":              txLog.SwapID,
+			"destination_chain_id": txLog.DestinationChainID,
+			"update_time":          time.Now().Unix(),
+		}).Error
+}
+
 // ConfirmWorkerTx ...
 func (d *DataBase) ConfirmWorkerTx(chainID string, txLogs []*TxLog, txHashes []string, newEvents []*Event) error {
 	tx := d.db.Begin()
@@ -52,6 +92,8 @@ func (d *DataBase) ConfirmWorkerTx(chainID string, txLogs []*TxLog, txHashes []s
 			}
 		} else {
 			swap.Status = previousSwap.Status
+			// swap is created by the event of the first chain, its age is counted from it
+			swap.CreateTime = previousSwap.CreateTime
 			if err := tx.Model(Event{}).Where("swap_id = ?", swap.SwapID).Update(swap).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -135,6 +177,33 @@ func (d *DataBase) UpdateTxSentStatus(txSent *TxSent, status TxStatus) error {
 		}).Error
 }
 
+// UpdateTxSentErrMsg ...
+func (d *DataBase) UpdateTxSentErrMsg(txSent *TxSent, errMsg string) error {
+	return d.db.Model(TxSent{}).Where("id = ? and swap_id = ?", txSent.ID, txSent.SwapID).Update(
+		map[string]interface{}{
+			"err_msg":     errMsg,
+			"update_time": time.Now().Unix(),
+		}).Error
+}
+
+// CountTxsSentByStatus returns number of sent txs of the chain in every status
+func (d *DataBase) CountTxsSentByStatus(chain string) (map[TxStatus]int64, error) {
+	var rows []struct {
+		Status TxStatus
+		Count  int64
+	}
+	if err := d.db.Model(TxSent{}).Select("status, count(*) as count").Where("chain = ?", chain).
+		Group("status").Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	counts := make(map[TxStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	return counts, nil
+}
+
 // GetTxsSentByStatus ...
 func (d *DataBase) GetTxsSentByStatus(chain string) ([]*TxSent, error) {
 	txsSent := make([]*TxSent, 0)
@@ -156,17 +225,28 @@ func (d *DataBase) GetTxsSentByType(chain string, txType TxType, event *Event) [
 
 // GetTxSentByTxHash ...
 func (d *DataBase) GetTxSentByTxHash(txHash string) (string, error) {
+	txSent, err := d.GetTxSentByDepositTxHash(txHash)
+	if err != nil {
+		return "", err
+	}
+
+	return txSent.TxHash, nil
+}
+
+// GetTxSentByDepositTxHash returns PASSED tx of the swap by hash of deposit tx,
+// mined one is preferred over the latest one
+func (d *DataBase) GetTxSentByDepositTxHash(txHash string) (*TxSent, error) {
 	txLog := &TxLog{}
 	if err := d.db.Model(TxLog{}).Where("tx_hash = ? and tx_type = ?", txHash, TxTypeDeposit).
 		Find(&txLog).Error; err != nil {
-		return "", err
+		return nil, err
 	}
 
 	txSent := &TxSent{}
 	if err := d.db.Model(TxSent{}).Where("swap_id = ? and type = ?", txLog.SwapID, TxTypePassed).
-		Find(&txSent).Error; err != nil {
-		return "", err
+		Order(gorm.Expr("status = ? desc, id desc", TxSentStatusSuccess)).First(&txSent).Error; err != nil {
+		return nil, err
 	}
 
-	return txSent.TxHash, nil
+	return txSent, nil
 }
@@ -18,6 +18,8 @@ const (
 	TxSentStatusFailed   TxStatus = "FAILED"
 	TxSentStatusSuccess  TxStatus = "SUCCESS"
 	TxSentStatusLost     TxStatus = "LOST"
+	// TxSentStatusReverted is status of tx reverted in simulation, it is never broadcasted
+	TxSentStatusReverted TxStatus = "REVERTED"
 )
 
 // !!! TODO !!!
@@ -31,8 +33,46 @@ const (
 	TxTypeSpend   TxType = "SPEND"
 	TxTypeExpired TxType = "EXPIRED"
 	TxTypeUpdate  TxType = "UPDATE"
+
+	TxTypeProposalVote            TxType = "PROPOSAL_VOTE"
+	TxTypeExtraFeeTransferred     TxType = "EXTRA_FEE_TRANSFERRED"
+	TxTypeExtraFeeSupplied        TxType = "EXTRA_FEE_SUPPLIED"
+	TxTypeRewardCollected         TxType = "REWARD_COLLECTED"
+	TxTypeRelayerThresholdChanged TxType = "RELAYER_THRESHOLD_CHANGED"
+	TxTypePaused                  TxType = "PAUSED"
+	TxTypeUnpaused                TxType = "UNPAUSED"
+	TxTypeOwnershipTransferred    TxType = "OWNERSHIP_TRANSFERRED"
 )
 
+// auditTxTypes are kept in 'tx_logs' as history of swaps and relayers only
+var auditTxTypes = []TxType{
+	TxTypeProposalVote,
+	TxTypeExtraFeeTransferred,
+	TxTypeExtraFeeSupplied,
+	TxTypeRewardCollected,
+	TxTypeRelayerThresholdChanged,
+	TxTypePaused,
+	TxTypeUnpaused,
+	TxTypeOwnershipTransferred,
+}
+
+// securityTxTypes are admin actions on bridge and handler contracts
+var securityTxTypes = []TxType{
+	TxTypePaused,
+	TxTypeUnpaused,
+	TxTypeOwnershipTransferred,
+}
+
+// IsAudit returns true if tx of the type never creates events or changes their status
+func (t TxType) IsAudit() bool {
+	for _, txType := range auditTxTypes {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
 type EventStatus string
 
 const (
@@ -54,6 +94,56 @@ const (
 
 	EventStatusUpdateConfirmed EventStatus = "UPDATE_CONFIRMED"
 	EventStatusUpdateFailed    EventStatus = "UPDATE_FAILED"
+
+	// swap refunded manually by operator
+	EventStatusRefunded EventStatus = "REFUNDED"
+)
+
+// PendingEventStatuses are statuses of swaps not finished yet,
+// swap is finished when its status is updated on LA or it is spent or expired,
+// swap with failed update is not finished until update is requeued or swap is refunded
+var PendingEventStatuses = []EventStatus{
+	EventStatusDepositConfirmed,
+	EventStatusClaimConfirmed,
+	EventStatusPassedInit,
+	EventStatusPassedInitConfrimed,
+	EventStatusPassedSent,
+	EventStatusPassedSentFailed,
+	EventStatusPassedConfirmed,
+	EventStatusPassedFailed,
+	EventStatusUpdateFailed,
+}
+
+// IsSecurity returns true if tx of the type is admin action on contract
+func (t TxType) IsSecurity() bool {
+	for _, txType := range securityTxTypes {
+		if t == txType {
+			return true
+		}
+	}
+	return false
+}
+
+// SecurityEventType ...
+type SecurityEventType string
+
+const (
+	SecurityEventPaused                SecurityEventType = "PAUSED"
+	SecurityEventUnpaused              SecurityEventType = "UNPAUSED"
+	SecurityEventOwnershipTransferred  SecurityEventType = "OWNERSHIP_TRANSFERRED"
+	SecurityEventImplementationChanged SecurityEventType = "IMPLEMENTATION_CHANGED"
+	SecurityEventProxyOwnerChanged     SecurityEventType = "PROXY_OWNER_CHANGED"
+)
+
+// ProposalStatus is status of the proposal in bridge contract
+type ProposalStatus uint8
+
+const (
+	ProposalStatusInactive ProposalStatus = iota
+	ProposalStatusActive
+	ProposalStatusPassed
+	ProposalStatusExecuted
+	ProposalStatusCancelled
 )
 
 // TxLogStatus ...
@@ -63,3 +153,14 @@ const (
 	TxStatusInit      TxLogStatus = "INIT"
 	TxStatusConfirmed TxLogStatus = "CONFIRMED"
 )
+
+// QuarantineStatus ...
+type QuarantineStatus string
+
+const (
+	QuarantineStatusQuarantined QuarantineStatus = "QUARANTINED"
+	QuarantineStatusReleased    QuarantineStatus = "RELEASED"
+)
+
+// EventStatusChannel is postgres channel notified on every status change of event
+const EventStatusChannel = "event_status"
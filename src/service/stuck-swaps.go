@@ -0,0 +1,133 @@
+package rlr
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/alerts"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+const (
+	defaultSLACheckInterval = 60
+	// seconds for swap to reach terminal status if route threshold is not configured
+	defaultSLAThreshold = 1800
+)
+
+// StuckSwapsMonitor periodically scans 'events' for swaps not finished within SLA of their route,
+// newly stuck swaps are alerted once, swaps which left pending statuses are dropped
+func (r *BridgeSRV) StuckSwapsMonitor() {
+	interval := r.slaConfig.CheckInterval
+	if interval <= 0 {
+		interval = defaultSLACheckInterval
+	}
+
+	for {
+		stuck, err := r.findStuckSwaps()
+		if err != nil {
+			r.logger.Errorf("find stuck swaps error, err = %v", err)
+		} else {
+			r.setStuckSwaps(stuck)
+		}
+		time.Sleep(time.Duration(interval) * time.Second)
+	}
+}
+
+// GetStuckSwaps returns swaps found stuck by the last scan from the oldest
+func (r *BridgeSRV) GetStuckSwaps() []*models.StuckSwap {
+	r.RLock()
+	defer r.RUnlock()
+
+	stuck := make([]*models.StuckSwap, 0, len(r.stuckSwaps))
+	for _, swap := range r.stuckSwaps {
+		stuck = append(stuck, swap)
+	}
+	sort.Slice(stuck, func(i, j int) bool {
+		return stuck[i].DepositTime < stuck[j].DepositTime
+	})
+	return stuck
+}
+
+func (r *BridgeSRV) findStuckSwaps() (map[string]*models.StuckSwap, error) {
+	now := time.Now().Unix()
+	events, err := r.storage.GetEventsCreatedBefore(storage.PendingEventStatuses, now-r.minSLAThreshold())
+	if err != nil {
+		return nil, err
+	}
+
+	stuck := make(map[string]*models.StuckSwap)
+	for _, event := range events {
+		route := r.routeName(event)
+		threshold := r.slaThreshold(route)
+		if age := now - event.CreateTime; age > threshold {
+			stuck[event.SwapID] = &models.StuckSwap{
+				SwapID:      event.SwapID,
+				Route:       route,
+				Status:      string(event.Status),
+				Age:         age,
+				Threshold:   threshold,
+				DepositTime: event.CreateTime,
+				UpdateTime:  event.UpdateTime,
+			}
+		}
+	}
+	return stuck, nil
+}
+
+// setStuckSwaps replaces result of the previous scan, alerts swaps which were not stuck before
+func (r *BridgeSRV) setStuckSwaps(stuck map[string]*models.StuckSwap) {
+	r.Lock()
+	previous := r.stuckSwaps
+	r.stuckSwaps = stuck
+	r.Unlock()
+	setStuckSwapsMetric(stuck)
+
+	for swapID, swap := range stuck {
+		if _, ok := previous[swapID]; !ok {
+			r.alerter.Raise(alerts.LevelWarning, swap.Route, "stuck swap",
+				fmt.Sprintf("swap %s is in status %s for %ds, threshold %ds", swapID, swap.Status, swap.Age, swap.Threshold))
+		}
+	}
+	for swapID, swap := range previous {
+		if _, ok := stuck[swapID]; !ok {
+			r.logger.Infof("swap %s of route %s is not stuck anymore", swapID, swap.Route)
+		}
+	}
+}
+
+// routeName returns route of the swap like "ETH-LA" by names of chains
+func (r *BridgeSRV) routeName(event *storage.Event) string {
+	return fmt.Sprintf("%s-%s", r.chainNameByID(event.OriginChainID), r.chainNameByID(event.DestinationChainID))
+}
+
+func (r *BridgeSRV) chainNameByID(chainID string) string {
+	for _, worker := range r.Workers {
+		if worker.GetDestinationID() == chainID {
+			return worker.GetChainName()
+		}
+	}
+	return chainID
+}
+
+func (r *BridgeSRV) slaThreshold(route string) int64 {
+	if threshold, ok := r.slaConfig.Routes[route]; ok && threshold > 0 {
+		return threshold
+	}
+	if r.slaConfig.Threshold > 0 {
+		return r.slaConfig.Threshold
+	}
+	return defaultSLAThreshold
+}
+
+// minSLAThreshold bounds events fetched from database, older ones are checked against their route
+func (r *BridgeSRV) minSLAThreshold() int64 {
+	min := r.slaThreshold("")
+	for _, threshold := range r.slaConfig.Routes {
+		if threshold > 0 && threshold < min {
+			min = threshold
+		}
+	}
+	return min
+}
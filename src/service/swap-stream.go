@@ -0,0 +1,131 @@
+package rlr
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// updates buffered for subscriber, slow subscriber misses updates above it
+	swapStreamBuffer = 64
+	// postgres listener reconnect intervals
+	listenerMinReconnect = 10 * time.Second
+	listenerMaxReconnect = time.Minute
+)
+
+// SwapStream fans out status changes of swaps notified by postgres to subscribers of this instance
+type SwapStream struct {
+	sync.RWMutex
+	logger      *logrus.Entry
+	dbURL       string
+	subscribers map[*SwapSubscription]struct{}
+}
+
+// SwapSubscription receives updates of the swap or of swaps of the address, empty filter receives all updates
+type SwapSubscription struct {
+	SwapID  string
+	Address string
+	Updates chan *models.SwapUpdate
+}
+
+// NewSwapStream ...
+func NewSwapStream(logger *logrus.Logger, dbURL string) *SwapStream {
+	return &SwapStream{
+		logger:      logger.WithField("layer", "swap-stream"),
+		dbURL:       dbURL,
+		subscribers: make(map[*SwapSubscription]struct{}),
+	}
+}
+
+// Run listens postgres channel of event statuses
+func (s *SwapStream) Run() {
+	listener := pq.NewListener(s.dbURL, listenerMinReconnect, listenerMaxReconnect, func(ev pq.ListenerEventType, err error) {
+		if err != nil {
+			s.logger.Errorf("listener event %d, err = %v", ev, err)
+		}
+	})
+	if err := listener.Listen(storage.EventStatusChannel); err != nil {
+		s.logger.Errorf("listen %s error, err = %v", storage.EventStatusChannel, err)
+		return
+	}
+
+	go func() {
+		for notification := range listener.Notify {
+			// nil is sent after reconnect, updates during reconnect are lost
+			if notification == nil {
+				continue
+			}
+
+			var update models.SwapUpdate
+			if err := json.Unmarshal([]byte(notification.Extra), &update); err != nil {
+				s.logger.Errorf("unmarshal swap update error, err = %v", err)
+				continue
+			}
+			s.publish(&update)
+		}
+	}()
+}
+
+// Subscribe registers subscription, it must be cancelled by Unsubscribe
+func (s *SwapStream) Subscribe(swapID, address string) *SwapSubscription {
+	sub := &SwapSubscription{
+		SwapID:  swapID,
+		Address: address,
+		Updates: make(chan *models.SwapUpdate, swapStreamBuffer),
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.subscribers[sub] = struct{}{}
+	return sub
+}
+
+// Unsubscribe ...
+func (s *SwapStream) Unsubscribe(sub *SwapSubscription) {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.subscribers, sub)
+}
+
+func (s *SwapStream) publish(update *models.SwapUpdate) {
+	s.RLock()
+	defer s.RUnlock()
+
+	for sub := range s.subscribers {
+		if !sub.matches(update) {
+			continue
+		}
+		select {
+		case sub.Updates <- update:
+		default:
+			s.logger.Warnf("subscriber is too slow, update of swap %s is dropped", update.SwapID)
+		}
+	}
+}
+
+func (sub *SwapSubscription) matches(update *models.SwapUpdate) bool {
+	if sub.SwapID != "" && sub.SwapID != update.SwapID {
+		return false
+	}
+	if sub.Address != "" && sub.Address != update.SenderAddr && sub.Address != update.ReceiverAddr {
+		return false
+	}
+	return true
+}
+
+// SubscribeSwaps subscribes to status changes of the swap or of swaps of the address
+func (r *BridgeSRV) SubscribeSwaps(swapID, address string) (*SwapSubscription, error) {
+	address, err := normalizeAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSwapsQuery, err)
+	}
+	return r.Stream.Subscribe(swapID, address), nil
+}
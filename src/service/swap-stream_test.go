@@ -0,0 +1,41 @@
+package rlr
+
+import (
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+)
+
+func TestSwapSubscriptionMatches(t *testing.T) {
+	const (
+		sender   = "0x8F4A5fb5B2B9E46dE5E3aD2A1F2cd62d2A6D5C21"
+		receiver = "0x1f9840a85d5aF5bf1D1762F925BDADdC4201F984"
+	)
+	update := &models.SwapUpdate{SwapID: "0x01", SenderAddr: sender, ReceiverAddr: receiver}
+
+	tests := []struct {
+		name    string
+		swapID  string
+		address string
+		want    bool
+	}{
+		{"all swaps", "", "", true},
+		{"swap", "0x01", "", true},
+		{"other swap", "0x02", "", false},
+		{"sender", "", sender, true},
+		{"receiver", "", receiver, true},
+		{"other address", "", "0x0000000000000000000000000000000000000001", false},
+		{"swap and its address", "0x01", sender, true},
+		{"swap and other address", "0x01", "0x0000000000000000000000000000000000000001", false},
+		{"other swap of address", "0x02", receiver, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := &SwapSubscription{SwapID: tt.swapID, Address: tt.address}
+			if got := sub.matches(update); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
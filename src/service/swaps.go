@@ -0,0 +1,156 @@
+package rlr
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetSwap returns swap with its txs by swap id
+func (r *BridgeSRV) GetSwap(swapID string) (*models.SwapDetails, error) {
+	event, err := r.storage.GetEventBySwapID(swapID)
+	if err != nil {
+		return nil, err
+	}
+	return r.swapDetails(event)
+}
+
+// GetSwapByDepositTxHash returns swap with its txs by hash of deposit tx
+func (r *BridgeSRV) GetSwapByDepositTxHash(txHash string) (*models.SwapDetails, error) {
+	event, err := r.storage.GetEventByDepositTxHash(txHash)
+	if err != nil {
+		return nil, err
+	}
+	return r.swapDetails(event)
+}
+
+func (r *BridgeSRV) swapDetails(event *storage.Event) (*models.SwapDetails, error) {
+	txLogs, err := r.storage.GetTxLogsBySwapID(event.SwapID)
+	if err != nil {
+		return nil, err
+	}
+	txsSent, err := r.storage.GetTxsSentBySwapID(event.SwapID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.SwapDetails{
+		Swap:             event,
+		OriginChain:      r.chainNameByID(event.OriginChainID),
+		DestinationChain: r.chainNameByID(event.DestinationChainID),
+		Token:            r.storage.FetchResourceID(event.ResourceID).Name,
+		TxLogs:           txLogs,
+		TxsSent:          txsSent,
+	}, nil
+}
+
+// ErrInvalidSwapsQuery is returned for swaps query with unknown chain, malformed address or cursor
+var ErrInvalidSwapsQuery = errors.New("invalid swaps query")
+
+// SearchSwaps returns page of swaps by query
+func (r *BridgeSRV) SearchSwaps(query *models.SwapsQuery) (*models.SwapsPage, error) {
+	filter, err := r.swapsFilter(query)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSwapsQuery, err)
+	}
+
+	events, err := r.storage.FindEvents(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// one more event is requested to know if there is the next page
+	page := &models.SwapsPage{Swaps: events}
+	if len(events) > query.Limit {
+		page.Swaps = events[:query.Limit]
+		last := page.Swaps[len(page.Swaps)-1]
+		page.NextCursor = encodeCursor(&storage.EventCursor{CreateTime: last.CreateTime, SwapID: last.SwapID})
+	}
+	return page, nil
+}
+
+func (r *BridgeSRV) swapsFilter(query *models.SwapsQuery) (*storage.EventFilter, error) {
+	filter := &storage.EventFilter{
+		ResourceID: strings.ToLower(query.ResourceID),
+		From:       query.From,
+		To:         query.To,
+		Desc:       query.Desc,
+		Limit:      query.Limit + 1,
+	}
+
+	var err error
+	if filter.SenderAddr, err = normalizeAddress(query.Sender); err != nil {
+		return nil, err
+	}
+	if filter.ReceiverAddr, err = normalizeAddress(query.Receiver); err != nil {
+		return nil, err
+	}
+	if filter.Address, err = normalizeAddress(query.Address); err != nil {
+		return nil, err
+	}
+	if filter.OriginChainID, err = r.chainIDByName(query.OriginChain); err != nil {
+		return nil, err
+	}
+	if filter.DestinationChainID, err = r.chainIDByName(query.DestinationChain); err != nil {
+		return nil, err
+	}
+	for _, status := range query.Statuses {
+		filter.Statuses = append(filter.Statuses, storage.EventStatus(strings.ToUpper(status)))
+	}
+	if query.Cursor != "" {
+		if filter.Cursor, err = decodeCursor(query.Cursor); err != nil {
+			return nil, err
+		}
+	}
+	return filter, nil
+}
+
+func (r *BridgeSRV) chainIDByName(name string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+	worker, ok := r.Workers[strings.ToUpper(name)]
+	if !ok {
+		return "", fmt.Errorf("unknown chain %s", name)
+	}
+	return worker.GetDestinationID(), nil
+}
+
+// normalizeAddress returns address in the form it is stored in 'events'
+func normalizeAddress(address string) (string, error) {
+	if address == "" {
+		return "", nil
+	}
+	if !common.IsHexAddress(address) {
+		return "", fmt.Errorf("invalid address %s", address)
+	}
+	return common.HexToAddress(address).Hex(), nil
+}
+
+// encodeCursor makes opaque pagination cursor from position of the last event of the page
+func encodeCursor(cursor *storage.EventCursor) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%s", cursor.CreateTime, cursor.SwapID)))
+}
+
+func decodeCursor(cursor string) (*storage.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	parts := strings.SplitN(string(data), ":", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	createTime, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	return &storage.EventCursor{CreateTime: createTime, SwapID: parts[1]}, nil
+}
@@ -0,0 +1,51 @@
+package rlr
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+)
+
+func TestCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor *storage.EventCursor
+	}{
+		{"swap id", &storage.EventCursor{CreateTime: 1650000000, SwapID: "0x8a7d"}},
+		{"swap id with separator", &storage.EventCursor{CreateTime: 1650000000, SwapID: "a:b"}},
+		{"zero time", &storage.EventCursor{CreateTime: 0, SwapID: "0x01"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := decodeCursor(encodeCursor(tt.cursor))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != *tt.cursor {
+				t.Fatalf("got %+v, want %+v", got, tt.cursor)
+			}
+		})
+	}
+}
+
+func TestDecodeInvalidCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "!!!"},
+		{"no separator", base64.RawURLEncoding.EncodeToString([]byte("1650000000"))},
+		{"invalid time", base64.RawURLEncoding.EncodeToString([]byte("abc:0x01"))},
+		{"empty swap id", base64.RawURLEncoding.EncodeToString([]byte("1650000000:"))},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if cursor, err := decodeCursor(tt.cursor); err == nil {
+				t.Fatalf("want error, got %+v", cursor)
+			}
+		})
+	}
+}
@@ -57,6 +57,10 @@ func (b *BridgeSRV) SendConfirmationLA(event *storage.Event) (string, error) {
 		liquidity, _ = wor.GetLiquidityIndex(wor.GetConfig().AmTokenHandlerAddress, wor.GetConfig().AMUSDTContractAddr)
 	}
 
+	if !b.canSign() {
+		return "", fmt.Errorf("relayer is in %s mode", b.GetMode())
+	}
+
 	if event.InAmount == "" || event.OutAmount == "" {
 		err := fmt.Errorf("Error in finding amounts")
 		txSent.ErrMsg = err.Error()
@@ -66,13 +70,21 @@ func (b *BridgeSRV) SendConfirmationLA(event *storage.Event) (string, error) {
 		return "", err
 	}
 
+	if b.isPaused(b.laWorker.GetChainName()) {
+		return "", fmt.Errorf("bridge contract of %s is paused", b.laWorker.GetChainName())
+	}
+
 	inAmount, _ := new(big.Int).SetString(event.InAmount, 10)
 	outAmount, _ := new(big.Int).SetString(event.OutAmount, 10)
 
 	txHash, err := b.laWorker.UpdateSwapStatusOnChain(event.DepositNonce, utils.StringToBytes8(event.OriginChainID), utils.StringToBytes8(event.DestinationChainID), utils.StringToBytes32(event.ResourceID), event.ReceiverAddr, outAmount, inAmount, liquidity, status)
 	if err != nil {
 		txSent.ErrMsg = err.Error()
-		txSent.Status = storage.TxSentStatusFailed
+		// tx with hash could reach the network despite the error, its status is checked by hash
+		txSent.TxHash = txHash
+		if txHash == "" {
+			txSent.Status = storage.TxSentStatusFailed
+		}
 		b.storage.CreateTxSent(txSent)
 		b.storage.UpdateEventStatus(event, storage.EventStatusUpdateFailed)
 		return "", err
@@ -0,0 +1,28 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrSubscriptionNotSupported is returned when the chain can not be watched by subscription
+var ErrSubscriptionNotSupported = errors.New("subscription is not supported")
+
+// RevertError is returned when tx reverts in simulation, so it is not sent
+type RevertError struct {
+	Reason string
+}
+
+func (e *RevertError) Error() string {
+	return fmt.Sprintf("execution reverted: %s", e.Reason)
+}
+
+// RescanError is returned by subscription when it got log of the block which is already passed,
+// blocks from Height have to be scanned again
+type RescanError struct {
+	Height int64
+}
+
+func (e *RescanError) Error() string {
+	return fmt.Sprintf("late log of block %d", e.Height)
+}
@@ -1,7 +1,6 @@
 package eth
 
 import (
-	"bytes"
 	"fmt"
 	"math/big"
 	"strings"
@@ -18,18 +17,15 @@ import (
 )
 
 const (
-	ProposalEventName = "ProposalEvent"
-	DepositEventName  = "Deposit"
+	ProposalEventName                = "ProposalEvent"
+	DepositEventName                 = "Deposit"
+	ProposalVoteEventName            = "ProposalVote"
+	ExtraFeeTransferredEventName     = "ExtraFeeTransferred"
+	ExtraFeeSuppliedEventName        = "ExtraFeeSupplied"
+	RewardCollectedEventName         = "RewardCollected"
+	RelayerThresholdChangedEventName = "RelayerThresholdChanged"
 )
 
-// Event Hash (SHA3)
-var (
-	ProposalEventHash = common.HexToHash("0x9686dcabd0450cad86a88df15a9d35b08b35d1b08a19008df37cf8538c467516")
-	DepositEventHash  = common.HexToHash("0x370525803ffa9a7c0e6adb3868e393dca45d8b42b2f62fd1f23ecfe99f6ce8fc")
-)
-
-var txStatus = make(map[string]uint8)
-
 // ProposalEvent represents a ProposalEvent event raised by the Bridge.sol contract.
 type ProposalEvent struct {
 	OriginChainID      [8]byte
@@ -56,26 +52,45 @@ type DepositEvent struct {
 	Raw                types.Log // Blockchain specific contextual infos
 }
 
-// monitors swap status till 5 mins then throw error
-func setTxMonitor(SwapID string, Status uint8) {
-	if Status == 1 {
-		txStatus[SwapID] = Status
-		go func(SwapID string, Status uint8) {
-			time.Sleep(5 * 60 * time.Second)
-			if NewStatus, ok := txStatus[SwapID]; ok {
-				if NewStatus < 3 {
-					fmt.Printf("ERROR[%s] SwapID %s stuck in status %d\n\n", time.Now().Format(time.RFC3339Nano), SwapID, NewStatus)
-				}
-				delete(txStatus, SwapID)
-			}
-		}(SwapID, Status)
-	} else {
-		if HashStatus, ok := txStatus[SwapID]; ok {
-			if HashStatus < Status {
-				txStatus[SwapID] = Status
-			}
-		}
-	}
+// ProposalVoteEvent represents a ProposalVote event raised by the LA Bridge.sol contract,
+// it doesn't carry destination chain, so swap is linked on confirmation
+type ProposalVoteEvent struct {
+	OriginChainID [8]byte
+	DepositNonce  uint64
+	Status        uint8
+	ResourceID    [32]byte
+}
+
+// ExtraFeeEvent represents ExtraFeeTransferred(LA) and ExtraFeeSupplied(ETH) events
+type ExtraFeeEvent struct {
+	OriginChainID      [8]byte
+	DestinationChainID [8]byte
+	DepositNonce       uint64
+	ResourceID         [32]byte
+	RecipientAddress   common.Address
+	Amount             *big.Int
+	TxType             storage.TxType
+}
+
+// extraFeeTransferred is ExtraFeeTransferred event as declared in LA bridge ABI
+type extraFeeTransferred struct {
+	OriginChainID      [8]byte
+	DestinationChainID [8]byte
+	DepositNonce       uint64
+	ResouceID          [32]byte
+	Recipient          common.Address
+	Amount             *big.Int
+}
+
+// RewardCollectedEvent represents a RewardCollected event raised by the LA Bridge.sol contract
+type RewardCollectedEvent struct {
+	Relayer common.Address
+	Amount  *big.Int
+}
+
+// RelayerThresholdChangedEvent represents a RelayerThresholdChanged event raised by the LA Bridge.sol contract
+type RelayerThresholdChangedEvent struct {
+	NewThreshold *big.Int
 }
 
 func ParseLAProposalEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
@@ -97,8 +112,6 @@ func ParseLAProposalEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
 	fmt.Printf("INFO[%s] DataHash: 0x%s\n", event_time, common.Bytes2Hex(ev.DataHash[:]))
 	fmt.Printf("INFO[%s] amount: %s\n\n", event_time, ev.Amount.String())
 
-	setTxMonitor(SwapID, ev.Status)
-
 	return ev, nil
 }
 
@@ -150,6 +163,75 @@ func ParseEthDepositEvent(log *types.Log) (ContractEvent, error) {
 	return ev, nil
 }
 
+// ParseProposalVoteEvent ...
+func ParseProposalVoteEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
+	var ev ProposalVoteEvent
+	if err := abi.UnpackIntoInterface(&ev, ProposalVoteEventName, log.Data); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[0x%s/%d] ProposalVote, status: %d\n", common.Bytes2Hex(ev.OriginChainID[:]), ev.DepositNonce, ev.Status)
+
+	return ev, nil
+}
+
+// ParseExtraFeeTransferredEvent ...
+func ParseExtraFeeTransferredEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
+	var transferred extraFeeTransferred
+	if err := abi.UnpackIntoInterface(&transferred, ExtraFeeTransferredEventName, log.Data); err != nil {
+		return nil, err
+	}
+
+	ev := ExtraFeeEvent{
+		OriginChainID:      transferred.OriginChainID,
+		DestinationChainID: transferred.DestinationChainID,
+		DepositNonce:       transferred.DepositNonce,
+		ResourceID:         transferred.ResouceID,
+		RecipientAddress:   transferred.Recipient,
+		Amount:             transferred.Amount,
+		TxType:             storage.TxTypeExtraFeeTransferred,
+	}
+	fmt.Printf("[%s] ExtraFeeTransferred, recipient: %s, amount: %s\n", ev.CalcutateSwapID(), ev.RecipientAddress.Hex(), ev.Amount.String())
+
+	return ev, nil
+}
+
+// ParseExtraFeeSuppliedEvent ...
+func ParseExtraFeeSuppliedEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
+	ev := ExtraFeeEvent{TxType: storage.TxTypeExtraFeeSupplied}
+	if err := abi.UnpackIntoInterface(&ev, ExtraFeeSuppliedEventName, log.Data); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[%s] ExtraFeeSupplied, recipient: %s, amount: %s\n", ev.CalcutateSwapID(), ev.RecipientAddress.Hex(), ev.Amount.String())
+
+	return ev, nil
+}
+
+// ParseRewardCollectedEvent ...
+func ParseRewardCollectedEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
+	var ev RewardCollectedEvent
+	if err := abi.UnpackIntoInterface(&ev, RewardCollectedEventName, log.Data); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("RewardCollected, relayer: %s, amount: %s\n", ev.Relayer.Hex(), ev.Amount.String())
+
+	return ev, nil
+}
+
+// ParseRelayerThresholdChangedEvent ...
+func ParseRelayerThresholdChangedEvent(abi *abi.ABI, log *types.Log) (ContractEvent, error) {
+	var ev RelayerThresholdChangedEvent
+	if err := abi.UnpackIntoInterface(&ev, RelayerThresholdChangedEventName, log.Data); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("RelayerThresholdChanged, new threshold: %s\n", ev.NewThreshold.String())
+
+	return ev, nil
+}
+
 // !!! TODO !!!
 func (ev ProposalEvent) CalcutateSwapID() string {
 	return utils.CalcutateSwapID(common.Bytes2Hex(ev.OriginChainID[:]), common.Bytes2Hex(ev.DestinationChainID[:]), fmt.Sprint(ev.DepositNonce))
@@ -204,22 +286,101 @@ func (ev DepositEvent) ToTxLog(chain string) *storage.TxLog {
 	}
 }
 
+// ToTxLog ...
+func (ev ProposalVoteEvent) ToTxLog(chain string) *storage.TxLog {
+	return &storage.TxLog{
+		Chain:         chain,
+		TxType:        storage.TxTypeProposalVote,
+		OriginChainID: common.Bytes2Hex(ev.OriginChainID[:]),
+		DepositNonce:  ev.DepositNonce,
+		SwapStatus:    ev.Status,
+		ResourceID:    common.Bytes2Hex(ev.ResourceID[:]),
+	}
+}
+
+// CalcutateSwapID ...
+func (ev ExtraFeeEvent) CalcutateSwapID() string {
+	return utils.CalcutateSwapID(common.Bytes2Hex(ev.OriginChainID[:]), common.Bytes2Hex(ev.DestinationChainID[:]), fmt.Sprint(ev.DepositNonce))
+}
+
+// ToTxLog ...
+func (ev ExtraFeeEvent) ToTxLog(chain string) *storage.TxLog {
+	return &storage.TxLog{
+		Chain:              chain,
+		TxType:             ev.TxType,
+		SwapID:             ev.CalcutateSwapID(),
+		DestinationChainID: common.Bytes2Hex(ev.DestinationChainID[:]),
+		OriginChainID:      common.Bytes2Hex(ev.OriginChainID[:]),
+		DepositNonce:       ev.DepositNonce,
+		ResourceID:         common.Bytes2Hex(ev.ResourceID[:]),
+		ReceiverAddr:       ev.RecipientAddress.Hex(),
+		OutAmount:          ev.Amount.String(),
+	}
+}
+
+// ToTxLog ...
+func (ev RewardCollectedEvent) ToTxLog(chain string) *storage.TxLog {
+	return &storage.TxLog{
+		Chain:        chain,
+		TxType:       storage.TxTypeRewardCollected,
+		ReceiverAddr: ev.Relayer.Hex(),
+		OutAmount:    ev.Amount.String(),
+	}
+}
+
+// ToTxLog ...
+func (ev RelayerThresholdChangedEvent) ToTxLog(chain string) *storage.TxLog {
+	return &storage.TxLog{
+		Chain:  chain,
+		TxType: storage.TxTypeRelayerThresholdChanged,
+		Data:   ev.NewThreshold.String(),
+	}
+}
+
 // ParseEvent ...
 func (w *Erc20Worker) parseEvent(log *types.Log) (ContractEvent, error) {
-	if bytes.Equal(log.Topics[0][:], ProposalEventHash[:]) {
-		if w.GetChainName() == "LA" {
-			abi, _ := abi.JSON(strings.NewReader(laBr.LaBrABI))
-			return ParseLAProposalEvent(&abi, log)
+	// handler contracts are watched for admin events only
+	if log.Address != w.contractAddr {
+		if len(log.Topics) > 0 && w.events[log.Topics[0]] == OwnershipTransferredEventName {
+			return ParseOwnershipTransferredEvent(log)
 		}
+		return nil, nil
+	}
+
+	if w.isUnknownEvent(log) {
+		return nil, errUnknownEvent
 	}
-	if bytes.Equal(log.Topics[0][:], DepositEventHash[:]) {
+	if _, ok := w.ignoredEvents[log.Topics[0]]; ok {
+		return nil, nil
+	}
+	name := w.events[log.Topics[0]]
+
+	abi, _ := abi.JSON(strings.NewReader(bridgeABI(w.chainName)))
+	switch name {
+	case PausedEventName, UnpausedEventName:
+		return ParsePausedEvent(&abi, log, name)
+	case OwnershipTransferredEventName:
+		return ParseOwnershipTransferredEvent(log)
+	case ProposalEventName:
+		return ParseLAProposalEvent(&abi, log)
+	case ProposalVoteEventName:
+		return ParseProposalVoteEvent(&abi, log)
+	case ExtraFeeTransferredEventName:
+		return ParseExtraFeeTransferredEvent(&abi, log)
+	case ExtraFeeSuppliedEventName:
+		return ParseExtraFeeSuppliedEvent(&abi, log)
+	case RewardCollectedEventName:
+		return ParseRewardCollectedEvent(&abi, log)
+	case RelayerThresholdChangedEventName:
+		return ParseRelayerThresholdChangedEvent(&abi, log)
+	case DepositEventName:
 		if w.chainName == "LA" {
 			return ParseLaDepositEvent(log)
 		} else {
 			return ParseEthDepositEvent(log)
 		}
 	}
-	return nil, nil
+	return nil, errUnknownEvent
 }
 
 // ContractEvent ...
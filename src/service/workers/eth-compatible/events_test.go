@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// packEvent returns log of the event with args packed by contract ABI
+func packEvent(t *testing.T, contractABI *abi.ABI, name string, args ...interface{}) *types.Log {
+	event := contractABI.Events[name]
+	data, err := event.Inputs.NonIndexed().Pack(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &types.Log{Address: common.HexToAddress("0xc1"), Topics: []common.Hash{event.ID}, Data: data}
+}
+
+func TestParseAuditEvents(t *testing.T) {
+	var (
+		origin      = [8]byte{1}
+		destination = [8]byte{2}
+		resource    = [32]byte{3}
+		recipient   = common.HexToAddress("0xa1")
+	)
+	const (
+		originHex   = "0100000000000000"
+		destHex     = "0200000000000000"
+		resourceHex = "0300000000000000000000000000000000000000000000000000000000000000"
+		swapID      = originHex + destHex + "7"
+	)
+
+	tests := []struct {
+		name  string
+		parse func(*abi.ABI, *types.Log) (ContractEvent, error)
+		abi   *abi.ABI
+		log   *types.Log
+		want  storage.TxLog
+	}{
+		{"proposal vote", ParseProposalVoteEvent, &laBridgeABI,
+			packEvent(t, &laBridgeABI, ProposalVoteEventName, origin, uint64(7), uint8(2), resource),
+			storage.TxLog{TxType: storage.TxTypeProposalVote, OriginChainID: originHex, DepositNonce: 7, SwapStatus: 2, ResourceID: resourceHex}},
+		// unpacked into extraFeeTransferred, its fields must match names of LA ABI inputs
+		{"extra fee transferred", ParseExtraFeeTransferredEvent, &laBridgeABI,
+			packEvent(t, &laBridgeABI, ExtraFeeTransferredEventName, origin, destination, uint64(7), resource, recipient, big.NewInt(500)),
+			storage.TxLog{TxType: storage.TxTypeExtraFeeTransferred, SwapID: swapID, OriginChainID: originHex, DestinationChainID: destHex,
+				DepositNonce: 7, ResourceID: resourceHex, ReceiverAddr: recipient.Hex(), OutAmount: "500"}},
+		{"extra fee supplied", ParseExtraFeeSuppliedEvent, &ethBridgeABI,
+			packEvent(t, &ethBridgeABI, ExtraFeeSuppliedEventName, origin, destination, uint64(7), resource, recipient, big.NewInt(500)),
+			storage.TxLog{TxType: storage.TxTypeExtraFeeSupplied, SwapID: swapID, OriginChainID: originHex, DestinationChainID: destHex,
+				DepositNonce: 7, ResourceID: resourceHex, ReceiverAddr: recipient.Hex(), OutAmount: "500"}},
+		{"reward collected", ParseRewardCollectedEvent, &laBridgeABI,
+			packEvent(t, &laBridgeABI, RewardCollectedEventName, recipient, big.NewInt(42)),
+			storage.TxLog{TxType: storage.TxTypeRewardCollected, ReceiverAddr: recipient.Hex(), OutAmount: "42"}},
+		{"relayer threshold changed", ParseRelayerThresholdChangedEvent, &laBridgeABI,
+			packEvent(t, &laBridgeABI, RelayerThresholdChangedEventName, big.NewInt(3)),
+			storage.TxLog{TxType: storage.TxTypeRelayerThresholdChanged, Data: "3"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := tt.parse(tt.abi, tt.log)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.want.Chain = "LA"
+			if got := ev.ToTxLog("LA"); *got != tt.want {
+				t.Fatalf("got tx log %+v, want %+v", got, tt.want)
+			}
+
+			// truncated log is never parsed partially
+			truncated := &types.Log{Address: tt.log.Address, Topics: tt.log.Topics, Data: tt.log.Data[:len(tt.log.Data)-1]}
+			if ev, err := tt.parse(tt.abi, truncated); err == nil {
+				t.Fatalf("want error for truncated data, got %+v", ev)
+			}
+		})
+	}
+}
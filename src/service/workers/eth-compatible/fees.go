@@ -0,0 +1,84 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+)
+
+const (
+	TxTypeLegacy     = "legacy"
+	TxTypeDynamicFee = "dynamic"
+
+	// number of blocks and reward percentile used to estimate priority fee
+	feeHistoryBlocks     = 10
+	feeHistoryPercentile = 50
+)
+
+// setFees sets gas price for legacy txs or fee caps for EIP-1559 txs depending on tx_type of the chain
+func (w *Erc20Worker) setFees(auth *bind.TransactOpts) error {
+	switch w.config.TxType {
+	case "", TxTypeLegacy:
+		auth.GasPrice = w.legacyGasPrice()
+	case TxTypeDynamicFee:
+		feeCap, tipCap, err := w.dynamicFees()
+		if err != nil {
+			return err
+		}
+		auth.GasFeeCap = feeCap
+		auth.GasTipCap = tipCap
+	default:
+		return fmt.Errorf("unknown tx type %s for chain %s", w.config.TxType, w.chainName)
+	}
+	return nil
+}
+
+// legacyGasPrice returns gas price from fetcher, or from config if fetcher has nothing
+func (w *Erc20Worker) legacyGasPrice() *big.Int {
+	var gasPrice float64
+	gasPriceGWei, _ := strconv.ParseFloat(w.storage.GetGasPrice(w.chainName).Price, 64)
+	if gasPriceGWei > 0 {
+		gasPrice = gasPriceGWei * 1000000000
+	} else {
+		gasPrice = w.GetGasPrice()
+	}
+	return big.NewInt((int64(gasPrice)))
+}
+
+// dynamicFees returns maxFeePerGas and maxPriorityFeePerGas from eth_feeHistory,
+// falls back to gas price from fetcher, both are capped by config
+func (w *Erc20Worker) dynamicFees() (feeCap *big.Int, tipCap *big.Int, err error) {
+	history, err := w.client.FeeHistory(context.Background(), feeHistoryBlocks, nil, []float64{feeHistoryPercentile})
+	if err != nil || len(history.BaseFee) == 0 {
+		w.logger.Warnf("fee history is not available, fallback to gas price, err = %v", err)
+		gasPrice := w.legacyGasPrice()
+		feeCap, tipCap = gasPrice, new(big.Int).Set(gasPrice)
+	} else {
+		tipCap = new(big.Int)
+		for _, reward := range history.Reward {
+			if len(reward) > 0 {
+				tipCap.Add(tipCap, reward[0])
+			}
+		}
+		if len(history.Reward) > 0 {
+			tipCap.Div(tipCap, big.NewInt(int64(len(history.Reward))))
+		}
+		// base fee of the next block is the last one, doubled to survive a few full blocks
+		baseFee := history.BaseFee[len(history.BaseFee)-1]
+		feeCap = new(big.Int).Add(new(big.Int).Mul(baseFee, big.NewInt(2)), tipCap)
+	}
+
+	if maxTip := w.config.MaxPriorityFeePerGas; maxTip != nil && maxTip.Sign() > 0 && tipCap.Cmp(maxTip) > 0 {
+		tipCap = new(big.Int).Set(maxTip)
+	}
+	if maxFee := w.config.MaxFeePerGas; maxFee != nil && maxFee.Sign() > 0 && feeCap.Cmp(maxFee) > 0 {
+		feeCap = new(big.Int).Set(maxFee)
+	}
+	if tipCap.Cmp(feeCap) > 0 {
+		tipCap = new(big.Int).Set(feeCap)
+	}
+	return feeCap, tipCap, nil
+}
@@ -0,0 +1,207 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// nonceSource is chain state of the worker account
+type nonceSource interface {
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error)
+}
+
+// nonceStore keeps the last used nonce of the worker
+type nonceStore interface {
+	GetNonce(chain string) storage.Nonce
+	SaveNonce(nonce *storage.Nonce) error
+}
+
+// nonceManager hands out nonces of the worker account locally, so sends in quick succession
+// never race to the same nonce, and keeps the last used nonce in database.
+// next never goes back, nonces released without broadcast are handed out again before it
+type nonceManager struct {
+	sync.Mutex
+	chain   string
+	address common.Address
+	client  nonceSource
+	storage nonceStore
+	logger  *logrus.Entry
+	next    uint64
+	// nonces handed out and neither broadcasted nor released yet
+	inFlight map[uint64]bool
+	// released nonces ordered ascending
+	free []uint64
+}
+
+func newNonceManager(logger *logrus.Entry, chain string, address common.Address, client nonceSource, db nonceStore) *nonceManager {
+	return &nonceManager{
+		chain:    chain,
+		address:  address,
+		client:   client,
+		storage:  db,
+		logger:   logger.WithField("layer", "nonce"),
+		inFlight: make(map[uint64]bool),
+	}
+}
+
+// resync takes next nonce from pending state of the chain, it is called on start before any nonce is handed out
+func (n *nonceManager) resync() error {
+	n.Lock()
+	defer n.Unlock()
+
+	pending, err := n.client.PendingNonceAt(context.Background(), n.address)
+	if err != nil {
+		return err
+	}
+
+	if last := n.storage.GetNonce(n.chain); last.Chain != "" && last.Nonce >= pending {
+		n.logger.Warnf("nonce gap, last used nonce = %d, chain pending nonce = %d", last.Nonce, pending)
+	}
+	n.logger.Infof("nonce synced with chain, next nonce = %d", pending)
+	n.next = pending
+	n.free = nil
+	return nil
+}
+
+// catchUp moves next forward to pending nonce of the chain if the account is used by someone else,
+// released nonces already taken on chain are dropped
+func (n *nonceManager) catchUp() error {
+	pending, err := n.client.PendingNonceAt(context.Background(), n.address)
+	if err != nil {
+		return err
+	}
+
+	free := n.free[:0]
+	for _, nonce := range n.free {
+		if nonce >= pending {
+			free = append(free, nonce)
+		}
+	}
+	n.free = free
+	if pending > n.next {
+		n.logger.Warnf("nonce moved by chain from %d to %d", n.next, pending)
+		n.next = pending
+	}
+	return nil
+}
+
+// acquire returns next nonce of the worker account
+func (n *nonceManager) acquire() uint64 {
+	n.Lock()
+	defer n.Unlock()
+
+	var nonce uint64
+	if len(n.free) > 0 {
+		nonce, n.free = n.free[0], n.free[1:]
+	} else {
+		nonce = n.next
+		n.next++
+	}
+	n.inFlight[nonce] = true
+	return nonce
+}
+
+// commit saves nonce of the broadcasted tx as the last used one
+func (n *nonceManager) commit(nonce uint64) {
+	n.Lock()
+	defer n.Unlock()
+
+	delete(n.inFlight, nonce)
+	if last := n.storage.GetNonce(n.chain); last.Chain != "" && last.Nonce > nonce {
+		return
+	}
+	if err := n.storage.SaveNonce(&storage.Nonce{Chain: n.chain, Address: n.address.Hex(), Nonce: nonce}); err != nil {
+		n.logger.Errorf("save nonce %d error, err = %v", nonce, err)
+	}
+}
+
+// release gives back nonce of the tx which was not broadcasted, it is handed out again by the next acquire.
+// Chain is checked on 'nonce too low' or when no other nonce is in flight, so local state catches up
+// with txs sent by someone else
+func (n *nonceManager) release(nonce uint64, sendErr error) {
+	n.Lock()
+	defer n.Unlock()
+
+	delete(n.inFlight, nonce)
+	tooLow := strings.Contains(strings.ToLower(sendErr.Error()), "nonce too low")
+	if !tooLow {
+		i := sort.Search(len(n.free), func(i int) bool { return n.free[i] >= nonce })
+		if i == len(n.free) || n.free[i] != nonce {
+			n.free = append(n.free, 0)
+			copy(n.free[i+1:], n.free[i:])
+			n.free[i] = nonce
+		}
+	}
+	if tooLow || len(n.inFlight) == 0 {
+		if err := n.catchUp(); err != nil {
+			n.logger.Errorf("check chain nonce error, err = %v", err)
+		}
+	}
+}
+
+// rejectedTxErrors are errors returned by the node for tx refused before it is broadcasted
+var rejectedTxErrors = []string{
+	"nonce too low",
+	"insufficient funds",
+	"intrinsic gas too low",
+	"exceeds block gas limit",
+	"transaction underpriced",
+	"less than block base fee",
+	"max priority fee per gas higher than max fee per gas",
+	"invalid sender",
+	"oversized data",
+}
+
+// isRejectedTx returns true if the node answered that it refused tx, so its nonce can be released.
+// Transport errors and timeouts are ambiguous, the tx could be accepted by the node before them
+func isRejectedTx(err error) bool {
+	var rpcErr rpc.Error
+	if !errors.As(err, &rpcErr) {
+		return false
+	}
+	normalizedErr := strings.ToLower(err.Error())
+	for _, msg := range rejectedTxErrors {
+		if strings.Contains(normalizedErr, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// status returns local nonce state compared with the chain
+func (n *nonceManager) status() (models.NonceStatus, error) {
+	pending, err := n.client.PendingNonceAt(context.Background(), n.address)
+	if err != nil {
+		return models.NonceStatus{}, err
+	}
+	latest, err := n.client.NonceAt(context.Background(), n.address, nil)
+	if err != nil {
+		return models.NonceStatus{}, err
+	}
+
+	n.Lock()
+	next := n.next
+	released := append([]uint64{}, n.free...)
+	n.Unlock()
+
+	return models.NonceStatus{
+		Next:         next,
+		LastUsed:     n.storage.GetNonce(n.chain).Nonce,
+		ChainPending: pending,
+		ChainLatest:  latest,
+		Gap:          int64(next) - int64(pending),
+		Released:     released,
+	}, nil
+}
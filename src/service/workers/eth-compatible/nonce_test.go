@@ -0,0 +1,191 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/sirupsen/logrus"
+)
+
+type fakeNonceSource struct {
+	sync.Mutex
+	pending uint64
+}
+
+func (s *fakeNonceSource) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	s.Lock()
+	defer s.Unlock()
+	return s.pending, nil
+}
+
+func (s *fakeNonceSource) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return s.PendingNonceAt(ctx, account)
+}
+
+type fakeNonceStore struct {
+	sync.Mutex
+	nonce storage.Nonce
+}
+
+func (s *fakeNonceStore) GetNonce(chain string) storage.Nonce {
+	s.Lock()
+	defer s.Unlock()
+	return s.nonce
+}
+
+func (s *fakeNonceStore) SaveNonce(nonce *storage.Nonce) error {
+	s.Lock()
+	defer s.Unlock()
+	s.nonce = *nonce
+	return nil
+}
+
+func newTestNonceManager(t *testing.T, pending uint64) (*nonceManager, *fakeNonceSource) {
+	source := &fakeNonceSource{pending: pending}
+	n := newNonceManager(testLogger(), "ETH", common.Address{}, source, &fakeNonceStore{})
+	if err := n.resync(); err != nil {
+		t.Fatal(err)
+	}
+	return n, source
+}
+
+var errSend = errors.New("connection refused")
+
+func testLogger() *logrus.Entry {
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	return logrus.NewEntry(logger)
+}
+
+func TestNonceManagerReleaseKeepsLaterNonces(t *testing.T) {
+	n, source := newTestNonceManager(t, 5)
+
+	a := n.acquire()
+	b := n.acquire()
+	if a != 5 || b != 6 {
+		t.Fatalf("acquired %d, %d, want 5, 6", a, b)
+	}
+	// lagging provider must not move next back while b is broadcasting
+	source.pending = 3
+	n.release(a, errSend)
+
+	if c := n.acquire(); c != a {
+		t.Fatalf("released nonce is not reused, got %d, want %d", c, a)
+	}
+	if d := n.acquire(); d != 7 {
+		t.Fatalf("nonce %d handed out twice, want 7", d)
+	}
+}
+
+func TestNonceManagerRelease(t *testing.T) {
+	tests := []struct {
+		name     string
+		pending  uint64
+		err      error
+		wantNext []uint64
+	}{
+		{"send error reuses nonce", 5, errSend, []uint64{5, 7}},
+		{"nonce too low catches up with chain", 8, errors.New("nonce too low"), []uint64{8, 9}},
+		{"nonce too low with lagging chain keeps next", 4, errors.New("Nonce too low"), []uint64{7, 8}},
+		{"chain moved by someone else", 9, errSend, []uint64{9, 10}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, source := newTestNonceManager(t, 5)
+			nonce := n.acquire()
+			if nonce != 5 {
+				t.Fatalf("acquired %d, want 5", nonce)
+			}
+			n.commit(n.acquire())
+
+			source.pending = tt.pending
+			n.release(nonce, tt.err)
+			for _, want := range tt.wantNext {
+				if got := n.acquire(); got != want {
+					t.Fatalf("acquired %d, want %d", got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNonceManagerConcurrent(t *testing.T) {
+	n, _ := newTestNonceManager(t, 0)
+
+	var mu sync.Mutex
+	committed := make(map[uint64]bool)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 20; j++ {
+				nonce := n.acquire()
+				if (i+j)%3 == 0 {
+					n.release(nonce, errSend)
+					continue
+				}
+				mu.Lock()
+				if committed[nonce] {
+					t.Errorf("nonce %d committed twice", nonce)
+				}
+				committed[nonce] = true
+				mu.Unlock()
+				n.commit(nonce)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	// released nonces are reused, so committed ones have no gaps below the highest
+	var max uint64
+	for nonce := range committed {
+		if nonce > max {
+			max = nonce
+		}
+	}
+	free := make(map[uint64]bool)
+	for _, nonce := range n.free {
+		free[nonce] = true
+	}
+	for nonce := uint64(0); nonce <= max; nonce++ {
+		if !committed[nonce] && !free[nonce] {
+			t.Errorf("nonce %d is lost", nonce)
+		}
+	}
+}
+
+func TestIsRejectedTx(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nonce too low", &rpcError{"nonce too low"}, true},
+		{"insufficient funds", &rpcError{"insufficient funds for gas * price + value"}, true},
+		{"intrinsic gas", &rpcError{"intrinsic gas too low"}, true},
+		{"underpriced", &rpcError{"replacement transaction underpriced"}, true},
+		{"fee cap below base fee", &rpcError{"max fee per gas less than block base fee"}, true},
+		{"already known", &rpcError{"already known"}, false},
+		{"unknown node error", &rpcError{"internal error"}, false},
+		{"timeout", context.DeadlineExceeded, false},
+		{"connection reset", errors.New("read tcp: connection reset by peer"), false},
+		{"nonce too low without node answer", errors.New("nonce too low"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRejectedTx(tt.err); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
+	"github.com/latoken/bridge-backend-service/src/service/workers/utils"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetProposalStatus returns status of the proposal in bridge contract at the latest block,
+// eth bridge only knows whether proposal is executed
+func (w *Erc20Worker) GetProposalStatus(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string) (storage.ProposalStatus, error) {
+	callOpts := &bind.CallOpts{
+		From:    w.config.WorkerAddr,
+		Context: context.Background(),
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok {
+		return storage.ProposalStatusInactive, fmt.Errorf("invalid amount %q", amount)
+	}
+
+	if w.chainName == "LA" {
+		instance, err := laBr.NewLaBr(w.contractAddr, w.client)
+		if err != nil {
+			return storage.ProposalStatusInactive, err
+		}
+
+		proposal, err := instance.GetProposal(callOpts, originChainID, destinationChainID, depositNonce, common.HexToAddress(receiptAddr), value, resourceID)
+		if err != nil {
+			return storage.ProposalStatusInactive, err
+		}
+		return storage.ProposalStatus(proposal.Status), nil
+	}
+
+	instance, err := ethBr.NewEthBr(w.contractAddr, w.client)
+	if err != nil {
+		return storage.ProposalStatusInactive, err
+	}
+
+	nonceAndID, dataHash := utils.ProposalKeys(depositNonce, originChainID, destinationChainID, resourceID, common.HexToAddress(receiptAddr), value)
+	executed, err := instance.ExecutedProposals(callOpts, nonceAndID, dataHash)
+	if err != nil {
+		return storage.ProposalStatusInactive, err
+	}
+	if executed {
+		return storage.ProposalStatusExecuted, nil
+	}
+	return storage.ProposalStatusInactive, nil
+}
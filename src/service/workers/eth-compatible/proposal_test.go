@@ -0,0 +1,111 @@
+package eth
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math/big"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	"github.com/latoken/bridge-backend-service/src/service/workers/utils"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeBridge answers '_executedProposals(bytes32,bytes32)' calls of eth bridge contract
+type fakeBridge struct {
+	executed [][64]byte
+}
+
+type callArgs struct {
+	To   *common.Address `json:"to"`
+	Data hexutil.Bytes   `json:"data"`
+}
+
+func (b *fakeBridge) Call(args callArgs, block string) (hexutil.Bytes, error) {
+	result := make([]byte, 32)
+	selector := crypto.Keccak256([]byte("_executedProposals(bytes32,bytes32)"))[:4]
+	if len(args.Data) != 68 || !bytes.Equal(args.Data[:4], selector) {
+		return result, nil
+	}
+	for _, keys := range b.executed {
+		if bytes.Equal(args.Data[4:], keys[:]) {
+			result[31] = 1
+		}
+	}
+	return result, nil
+}
+
+// newTestClient serves eth namespace of rpc with the service in process
+func newTestClient(t *testing.T, service interface{}) *multiClient {
+	return &multiClient{
+		chain:     "ETH",
+		providers: []*provider{newTestProvider(t, "inproc", service)},
+		logger:    testLogger(),
+	}
+}
+
+// newTestProvider returns healthy provider serving eth namespace of rpc with the service in process
+func newTestProvider(t *testing.T, url string, service interface{}) *provider {
+	server := rpc.NewServer()
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	client := rpc.DialInProc(server)
+
+	return &provider{url: url, rpc: client, client: ethclient.NewClient(client), healthy: true}
+}
+
+func TestGetProposalStatus(t *testing.T) {
+	origin, destination := utils.StringToBytes8("0000000000000004"), utils.StringToBytes8("0000000000000001")
+	resourceID := utils.StringToBytes32("0000000000000000000000e9e7cea3dedca5984780bafc599bd69add087d5601")
+	recipient := "0x8f4a5fb5b2b9e46de5e3ad2a1f2cd62d2a6d5c21"
+	amount := "1000000000000000000"
+
+	// keys of executed proposal as bridge contract stores them
+	var executed [64]byte
+	nonce := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonce, 42)
+	copy(executed[:32], crypto.Keccak256(nonce, origin[:], destination[:]))
+	value, _ := new(big.Int).SetString(amount, 10)
+	copy(executed[32:], crypto.Keccak256(resourceID[:], common.HexToAddress(recipient).Bytes(), common.LeftPadBytes(value.Bytes(), 32)))
+
+	w := &Erc20Worker{
+		chainName:    "ETH",
+		config:       &models.WorkerConfig{},
+		contractAddr: common.HexToAddress("0x1"),
+		client:       newTestClient(t, &fakeBridge{executed: [][64]byte{executed}}),
+	}
+	tests := []struct {
+		name   string
+		nonce  uint64
+		amount string
+		want   storage.ProposalStatus
+	}{
+		{"executed", 42, amount, storage.ProposalStatusExecuted},
+		{"other nonce", 43, amount, storage.ProposalStatusInactive},
+		{"other amount", 42, "1", storage.ProposalStatusInactive},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, err := w.GetProposalStatus(tt.nonce, origin, destination, resourceID, recipient, tt.amount)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status != tt.want {
+				t.Fatalf("got status %d, want %d", status, tt.want)
+			}
+		})
+	}
+
+	if _, err := w.GetProposalStatus(42, origin, destination, resourceID, recipient, "1e18"); err == nil {
+		t.Fatal("want error for invalid amount")
+	}
+}
@@ -0,0 +1,544 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	"github.com/latoken/bridge-backend-service/src/service/metrics"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// seconds between health checks of providers
+	defaultProviderCheckInterval = 15
+	// blocks provider may be behind the highest head before it is treated as stale
+	defaultProviderMaxLag = 5
+	providerCheckTimeout  = 10 * time.Second
+	providerCallTimeout   = 30 * time.Second
+)
+
+// provider is a single rpc endpoint of the chain with its last known health
+type provider struct {
+	url     string
+	rpc     *rpc.Client
+	client  *ethclient.Client
+	height  int64
+	latency time.Duration
+	healthy bool
+	lastErr error
+	checked time.Time
+}
+
+// multiClient routes rpc calls of the worker to the best provider of the chain:
+// healthy, not lagging behind the others and with the lowest latency.
+// Failed provider is skipped until the next successful health check
+type multiClient struct {
+	sync.RWMutex
+	chain     string
+	providers []*provider
+	maxLag    int64
+	logger    *logrus.Entry
+}
+
+func newMultiClient(logger *logrus.Entry, cfg *models.WorkerConfig) (*multiClient, error) {
+	urls := cfg.Providers
+	if len(urls) == 0 && cfg.Provider != "" {
+		urls = []string{cfg.Provider}
+	}
+	if len(urls) == 0 {
+		return nil, fmt.Errorf("no providers configured")
+	}
+
+	maxLag := cfg.ProviderMaxLag
+	if maxLag <= 0 {
+		maxLag = defaultProviderMaxLag
+	}
+
+	c := &multiClient{
+		chain:  cfg.ChainName,
+		maxLag: maxLag,
+		logger: logger.WithField("layer", "providers"),
+	}
+	for _, u := range urls {
+		c.providers = append(c.providers, &provider{url: u})
+	}
+
+	c.check()
+	for _, p := range c.providers {
+		if p.healthy {
+			return c, nil
+		}
+	}
+	return nil, fmt.Errorf("all providers failed, last err = %v", c.providers[len(c.providers)-1].lastErr)
+}
+
+// run checks health of providers every interval
+func (c *multiClient) run(interval time.Duration) {
+	for {
+		time.Sleep(interval)
+		c.check()
+	}
+}
+
+// check fetches head of every provider and marks as unhealthy ones which failed
+// or lag more than maxLag blocks behind the highest head
+func (c *multiClient) check() {
+	var wg sync.WaitGroup
+	for _, p := range c.providers {
+		wg.Add(1)
+		go func(p *provider) {
+			defer wg.Done()
+			c.checkProvider(p)
+		}(p)
+	}
+	wg.Wait()
+
+	c.Lock()
+	defer c.Unlock()
+
+	var maxHeight int64
+	for _, p := range c.providers {
+		if p.lastErr == nil && p.height > maxHeight {
+			maxHeight = p.height
+		}
+	}
+	for _, p := range c.providers {
+		wasHealthy := p.healthy
+		p.healthy = p.lastErr == nil && maxHeight-p.height <= c.maxLag
+		if p.lastErr == nil && !p.healthy {
+			p.lastErr = fmt.Errorf("lagging %d blocks behind", maxHeight-p.height)
+		}
+		if wasHealthy && !p.healthy {
+			c.logger.Warnf("provider %s is unhealthy, err = %v", redactURL(p.url), p.lastErr)
+		} else if !wasHealthy && p.healthy {
+			c.logger.Infof("provider %s is healthy, height = %d, latency = %s", redactURL(p.url), p.height, p.latency)
+		}
+	}
+}
+
+func (c *multiClient) checkProvider(p *provider) {
+	ctx, cancel := context.WithTimeout(context.Background(), providerCheckTimeout)
+	defer cancel()
+
+	c.RLock()
+	client := p.client
+	c.RUnlock()
+
+	var height int64
+	var latency time.Duration
+	err := func() error {
+		if client == nil {
+			rpcClient, err := rpc.DialContext(ctx, p.url)
+			if err != nil {
+				return err
+			}
+			client = ethclient.NewClient(rpcClient)
+			c.Lock()
+			p.rpc, p.client = rpcClient, client
+			c.Unlock()
+		}
+
+		start := time.Now()
+		header, err := client.HeaderByNumber(ctx, nil)
+		if err != nil {
+			return err
+		}
+		height, latency = header.Number.Int64(), time.Since(start)
+		return nil
+	}()
+
+	c.Lock()
+	defer c.Unlock()
+	p.checked = time.Now()
+	p.lastErr = err
+	if err == nil {
+		p.height, p.latency = height, latency
+	}
+}
+
+// ranked returns dialed providers, healthy ones first ordered by latency,
+// then unhealthy ones as the last resort ordered by height
+func (c *multiClient) ranked() []*provider {
+	c.RLock()
+	defer c.RUnlock()
+
+	providers := make([]*provider, 0, len(c.providers))
+	for _, p := range c.providers {
+		if p.client != nil {
+			providers = append(providers, p)
+		}
+	}
+	sort.SliceStable(providers, func(i, j int) bool {
+		a, b := providers[i], providers[j]
+		if a.healthy != b.healthy {
+			return a.healthy
+		}
+		if a.healthy {
+			return a.latency < b.latency
+		}
+		return a.height > b.height
+	})
+	return providers
+}
+
+// subscriber returns the best healthy provider which supports subscriptions, ws:// or wss://
+func (c *multiClient) subscriber() (*provider, error) {
+	for _, p := range c.ranked() {
+		if !p.healthy {
+			break
+		}
+		if strings.HasPrefix(p.url, "ws://") || strings.HasPrefix(p.url, "wss://") {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("no healthy websocket providers")
+}
+
+// fail marks provider as unhealthy, so next calls fail over to another one
+func (c *multiClient) fail(p *provider, method string, err error) {
+	c.Lock()
+	defer c.Unlock()
+
+	if p.healthy {
+		c.logger.Warnf("provider %s failed on %s, fail over, err = %v", redactURL(p.url), method, err)
+	}
+	p.healthy = false
+	p.lastErr = err
+}
+
+// do calls providers in rank order until one of them answers, errors returned by
+// the node itself (reverts, not found, etc.) are not failures of the provider
+// unless they tell it is rate limited or behind the chain
+func (c *multiClient) do(ctx context.Context, method string, call func(ctx context.Context, p *provider) error) error {
+	providers := c.ranked()
+	if len(providers) == 0 {
+		return fmt.Errorf("no available providers")
+	}
+
+	var err error
+	for _, p := range providers {
+		callCtx, cancel := context.WithTimeout(ctx, providerCallTimeout)
+		err = call(callCtx, p)
+		cancel()
+		if err != nil && !errors.Is(err, ethereum.NotFound) {
+			metrics.RPCErrors.WithLabelValues(c.chain, method, redactURL(p.url)).Inc()
+		}
+		if !isProviderError(err) {
+			return err
+		}
+		c.fail(p, method, err)
+	}
+	return err
+}
+
+// pinned calls providers in rank order until one of them answers like do, but every request
+// made by call goes to the same provider, so their results are consistent with each other
+func (c *multiClient) pinned(method string, call func(client scanClient) error) error {
+	providers := c.ranked()
+	if len(providers) == 0 {
+		return fmt.Errorf("no available providers")
+	}
+
+	var err error
+	for _, p := range providers {
+		err = call(&pinnedClient{chain: c.chain, p: p})
+		if !isProviderError(err) {
+			return err
+		}
+		c.fail(p, method, err)
+	}
+	return err
+}
+
+// providerStateErrors are errors returned by the node when it is rate limited or
+// has not synced requested state, another provider may answer such request
+var providerStateErrors = []string{
+	"rate limit",
+	"too many requests",
+	"header not found",
+	"missing trie node",
+	"unknown block",
+}
+
+func isProviderError(err error) bool {
+	if err == nil || errors.Is(err, ethereum.NotFound) || errors.Is(err, errChainReorganised) {
+		return false
+	}
+	var rpcErr rpc.Error
+	var dataErr rpc.DataError
+	if !errors.As(err, &rpcErr) && !errors.As(err, &dataErr) {
+		return true
+	}
+	normalizedErr := strings.ToLower(err.Error())
+	for _, msg := range providerStateErrors {
+		if strings.Contains(normalizedErr, msg) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanClient is rpc api used to scan blocks, it is either multiClient or a single pinned provider
+type scanClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+	FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// pinnedClient makes all calls on one provider without fail over
+type pinnedClient struct {
+	chain string
+	p     *provider
+}
+
+func (c *pinnedClient) observe(method string, err error) error {
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		metrics.RPCErrors.WithLabelValues(c.chain, method, redactURL(c.p.url)).Inc()
+	}
+	return err
+}
+
+// CallContext performs raw rpc call
+func (c *pinnedClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, providerCallTimeout)
+	defer cancel()
+	return c.observe(method, c.p.rpc.CallContext(ctx, result, method, args...))
+}
+
+// HeaderByNumber ...
+func (c *pinnedClient) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	ctx, cancel := context.WithTimeout(ctx, providerCallTimeout)
+	defer cancel()
+	header, err := c.p.client.HeaderByNumber(ctx, number)
+	return header, c.observe("eth_getBlockByNumber", err)
+}
+
+// FilterLogs ...
+func (c *pinnedClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	ctx, cancel := context.WithTimeout(ctx, providerCallTimeout)
+	defer cancel()
+	logs, err := c.p.client.FilterLogs(ctx, q)
+	return logs, c.observe("eth_getLogs", err)
+}
+
+// status returns health of every provider
+func (c *multiClient) status() []models.ProviderStatus {
+	best := c.ranked()
+
+	c.RLock()
+	defer c.RUnlock()
+
+	statuses := make([]models.ProviderStatus, 0, len(c.providers))
+	for _, p := range c.providers {
+		status := models.ProviderStatus{
+			URL:       redactURL(p.url),
+			Height:    p.height,
+			Latency:   p.latency.Milliseconds(),
+			Healthy:   p.healthy,
+			Active:    len(best) > 0 && best[0] == p,
+			CheckedAt: p.checked,
+		}
+		if p.lastErr != nil {
+			status.Error = p.lastErr.Error()
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+// redactURL drops path and query of provider url, they often contain api keys
+func redactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return "<invalid url>"
+	}
+	return fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+}
+
+// CallContext performs raw rpc call
+func (c *multiClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	return c.do(ctx, method, func(ctx context.Context, p *provider) error {
+		return p.rpc.CallContext(ctx, result, method, args...)
+	})
+}
+
+// ChainID ...
+func (c *multiClient) ChainID(ctx context.Context) (chainID *big.Int, err error) {
+	err = c.do(ctx, "eth_chainId", func(ctx context.Context, p *provider) (err error) {
+		chainID, err = p.client.ChainID(ctx)
+		return
+	})
+	return
+}
+
+// HeaderByNumber ...
+func (c *multiClient) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = c.do(ctx, "eth_getBlockByNumber", func(ctx context.Context, p *provider) (err error) {
+		header, err = p.client.HeaderByNumber(ctx, number)
+		return
+	})
+	return
+}
+
+// TransactionByHash ...
+func (c *multiClient) TransactionByHash(ctx context.Context, hash common.Hash) (tx *types.Transaction, isPending bool, err error) {
+	err = c.do(ctx, "eth_getTransactionByHash", func(ctx context.Context, p *provider) (err error) {
+		tx, isPending, err = p.client.TransactionByHash(ctx, hash)
+		return
+	})
+	return
+}
+
+// TransactionReceipt ...
+func (c *multiClient) TransactionReceipt(ctx context.Context, hash common.Hash) (receipt *types.Receipt, err error) {
+	err = c.do(ctx, "eth_getTransactionReceipt", func(ctx context.Context, p *provider) (err error) {
+		receipt, err = p.client.TransactionReceipt(ctx, hash)
+		return
+	})
+	return
+}
+
+// BalanceAt ...
+func (c *multiClient) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (balance *big.Int, err error) {
+	err = c.do(ctx, "eth_getBalance", func(ctx context.Context, p *provider) (err error) {
+		balance, err = p.client.BalanceAt(ctx, account, blockNumber)
+		return
+	})
+	return
+}
+
+// CodeAt ...
+func (c *multiClient) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = c.do(ctx, "eth_getCode", func(ctx context.Context, p *provider) (err error) {
+		code, err = p.client.CodeAt(ctx, account, blockNumber)
+		return
+	})
+	return
+}
+
+// PendingCodeAt ...
+func (c *multiClient) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = c.do(ctx, "eth_getCode", func(ctx context.Context, p *provider) (err error) {
+		code, err = p.client.PendingCodeAt(ctx, account)
+		return
+	})
+	return
+}
+
+// NonceAt ...
+func (c *multiClient) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (nonce uint64, err error) {
+	err = c.do(ctx, "eth_getTransactionCount", func(ctx context.Context, p *provider) (err error) {
+		nonce, err = p.client.NonceAt(ctx, account, blockNumber)
+		return
+	})
+	return
+}
+
+// PendingNonceAt ...
+func (c *multiClient) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = c.do(ctx, "eth_getTransactionCount", func(ctx context.Context, p *provider) (err error) {
+		nonce, err = p.client.PendingNonceAt(ctx, account)
+		return
+	})
+	return
+}
+
+// CallContract ...
+func (c *multiClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (result []byte, err error) {
+	err = c.do(ctx, "eth_call", func(ctx context.Context, p *provider) (err error) {
+		result, err = p.client.CallContract(ctx, msg, blockNumber)
+		return
+	})
+	return
+}
+
+// PendingCallContract ...
+func (c *multiClient) PendingCallContract(ctx context.Context, msg ethereum.CallMsg) (result []byte, err error) {
+	err = c.do(ctx, "eth_call", func(ctx context.Context, p *provider) (err error) {
+		result, err = p.client.PendingCallContract(ctx, msg)
+		return
+	})
+	return
+}
+
+// SuggestGasPrice ...
+func (c *multiClient) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = c.do(ctx, "eth_gasPrice", func(ctx context.Context, p *provider) (err error) {
+		price, err = p.client.SuggestGasPrice(ctx)
+		return
+	})
+	return
+}
+
+// SuggestGasTipCap ...
+func (c *multiClient) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = c.do(ctx, "eth_maxPriorityFeePerGas", func(ctx context.Context, p *provider) (err error) {
+		tip, err = p.client.SuggestGasTipCap(ctx)
+		return
+	})
+	return
+}
+
+// FeeHistory ...
+func (c *multiClient) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (history *ethereum.FeeHistory, err error) {
+	err = c.do(ctx, "eth_feeHistory", func(ctx context.Context, p *provider) (err error) {
+		history, err = p.client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+		return
+	})
+	return
+}
+
+// EstimateGas ...
+func (c *multiClient) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (gas uint64, err error) {
+	err = c.do(ctx, "eth_estimateGas", func(ctx context.Context, p *provider) (err error) {
+		gas, err = p.client.EstimateGas(ctx, msg)
+		return
+	})
+	return
+}
+
+// SendTransaction broadcasts signed tx, resending the same tx to another provider is safe,
+// 'already known' after a failed attempt means the tx has reached the network
+func (c *multiClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	attempt := 0
+	return c.do(ctx, "eth_sendRawTransaction", func(ctx context.Context, p *provider) error {
+		attempt++
+		err := p.client.SendTransaction(ctx, tx)
+		if err != nil && attempt > 1 && strings.Contains(strings.ToLower(err.Error()), "already known") {
+			return nil
+		}
+		return err
+	})
+}
+
+// FilterLogs ...
+func (c *multiClient) FilterLogs(ctx context.Context, q ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = c.do(ctx, "eth_getLogs", func(ctx context.Context, p *provider) (err error) {
+		logs, err = p.client.FilterLogs(ctx, q)
+		return
+	})
+	return
+}
+
+// SubscribeFilterLogs ...
+func (c *multiClient) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = c.do(ctx, "eth_subscribe", func(ctx context.Context, p *provider) (err error) {
+		sub, err = p.client.SubscribeFilterLogs(ctx, q, ch)
+		return
+	})
+	return
+}
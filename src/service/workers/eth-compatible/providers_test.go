@@ -0,0 +1,296 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// ethclientStub marks provider as dialed for ranking, it is never called
+var ethclientStub = ethclient.NewClient(nil)
+
+// fakeProvider is a node at height, it answers every call with err when err is set,
+// methods are counted to check where requests went
+type fakeProvider struct {
+	sync.Mutex
+	height uint64
+	err    error
+	calls  map[string]int
+}
+
+func newFakeProvider(height uint64, err error) *fakeProvider {
+	return &fakeProvider{height: height, err: err, calls: make(map[string]int)}
+}
+
+func (p *fakeProvider) called(method string) error {
+	p.Lock()
+	defer p.Unlock()
+	p.calls[method]++
+	return p.err
+}
+
+func (p *fakeProvider) count(method string) int {
+	p.Lock()
+	defer p.Unlock()
+	return p.calls[method]
+}
+
+func (p *fakeProvider) ChainId() (*hexutil.Big, error) {
+	if err := p.called("eth_chainId"); err != nil {
+		return nil, err
+	}
+	return (*hexutil.Big)(big.NewInt(5)), nil
+}
+
+func (p *fakeProvider) GetBlockByNumber(number string, fullTx bool) (*types.Header, error) {
+	if err := p.called("eth_getBlockByNumber"); err != nil {
+		return nil, err
+	}
+	return &types.Header{Number: new(big.Int).SetUint64(p.height), Difficulty: big.NewInt(0)}, nil
+}
+
+func (p *fakeProvider) GetLogs(args filterArgs) ([]types.Log, error) {
+	if err := p.called("eth_getLogs"); err != nil {
+		return nil, err
+	}
+	return []types.Log{}, nil
+}
+
+func (p *fakeProvider) SendRawTransaction(data hexutil.Bytes) (common.Hash, error) {
+	if err := p.called("eth_sendRawTransaction"); err != nil {
+		return common.Hash{}, err
+	}
+	return common.Hash{}, nil
+}
+
+// newClosedProvider returns provider failing every call with transport error
+func newClosedProvider(t *testing.T, url string) *provider {
+	p := newTestProvider(t, url, newFakeProvider(100, nil))
+	p.rpc.Close()
+	return p
+}
+
+func newTestMultiClient(providers ...*provider) *multiClient {
+	return &multiClient{chain: "ETH", providers: providers, maxLag: defaultProviderMaxLag, logger: testLogger()}
+}
+
+func urls(providers []*provider) []string {
+	result := make([]string, 0, len(providers))
+	for _, p := range providers {
+		result = append(result, p.url)
+	}
+	return result
+}
+
+func TestRanked(t *testing.T) {
+	c := newTestMultiClient(
+		&provider{url: "unhealthy-low", client: ethclientStub, height: 90},
+		&provider{url: "slow", client: ethclientStub, healthy: true, latency: 300 * time.Millisecond},
+		&provider{url: "not-dialed", healthy: true},
+		&provider{url: "unhealthy-high", client: ethclientStub, height: 95},
+		&provider{url: "fast", client: ethclientStub, healthy: true, latency: 50 * time.Millisecond},
+	)
+
+	got := fmt.Sprint(urls(c.ranked()))
+	want := fmt.Sprint([]string{"fast", "slow", "unhealthy-high", "unhealthy-low"})
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestCheckProviders(t *testing.T) {
+	c := newTestMultiClient(
+		newTestProvider(t, "http://head", newFakeProvider(100, nil)),
+		newTestProvider(t, "http://behind", newFakeProvider(96, nil)),
+		newTestProvider(t, "http://lagging", newFakeProvider(90, nil)),
+		newClosedProvider(t, "http://closed"),
+	)
+
+	c.check()
+
+	tests := []struct {
+		url     string
+		healthy bool
+		height  int64
+	}{
+		{"http://head", true, 100},
+		{"http://behind", true, 96},
+		{"http://lagging", false, 90},
+		{"http://closed", false, 0},
+	}
+	for i, tt := range tests {
+		p := c.providers[i]
+		if p.healthy != tt.healthy || p.height != tt.height {
+			t.Errorf("%s: got healthy %v at %d, want %v at %d", tt.url, p.healthy, p.height, tt.healthy, tt.height)
+		}
+		if !tt.healthy && p.lastErr == nil {
+			t.Errorf("%s: unhealthy without error", tt.url)
+		}
+	}
+
+	ranked := c.ranked()
+	if !ranked[0].healthy || !ranked[1].healthy || ranked[2].url != "http://lagging" {
+		t.Fatalf("ranked %v, want healthy ones first and lagging before closed", urls(ranked))
+	}
+}
+
+func TestDoFailover(t *testing.T) {
+	tests := []struct {
+		name        string
+		first       error
+		wantErr     bool
+		wantFailed  bool
+		wantSecond  int
+		closedFirst bool
+	}{
+		{"transport error", nil, false, true, 1, true},
+		{"rate limited", errors.New("daily request rate limit reached"), false, true, 1, false},
+		{"behind the chain", errors.New("header not found"), false, true, 1, false},
+		{"node error", errors.New("execution reverted"), true, false, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			first := newTestProvider(t, "http://first", newFakeProvider(100, tt.first))
+			if tt.closedFirst {
+				first = newClosedProvider(t, "http://first")
+			}
+			second := newFakeProvider(100, nil)
+			c := newTestMultiClient(first, newTestProvider(t, "http://second", second))
+			first.latency, c.providers[1].latency = time.Millisecond, 2*time.Millisecond
+
+			chainID, err := c.ChainID(context.Background())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got chain id %v, err %v", chainID, err)
+			}
+			if !tt.wantErr && chainID.Int64() != 5 {
+				t.Fatalf("got chain id %s", chainID)
+			}
+			if first.healthy == tt.wantFailed {
+				t.Fatalf("first provider healthy = %v, want %v", first.healthy, !tt.wantFailed)
+			}
+			if got := second.count("eth_chainId"); got != tt.wantSecond {
+				t.Fatalf("second provider got %d calls, want %d", got, tt.wantSecond)
+			}
+		})
+	}
+}
+
+func TestDoAllProvidersFailed(t *testing.T) {
+	c := newTestMultiClient(newClosedProvider(t, "http://a"), newClosedProvider(t, "http://b"))
+	if _, err := c.ChainID(context.Background()); err == nil {
+		t.Fatal("want error when all providers failed")
+	}
+	for _, p := range c.providers {
+		if p.healthy {
+			t.Fatalf("provider %s is still healthy", p.url)
+		}
+	}
+}
+
+func TestPinnedRateLimited(t *testing.T) {
+	limited := newFakeProvider(100, nil)
+	second := newFakeProvider(100, nil)
+	c := newTestMultiClient(newTestProvider(t, "http://limited", limited), newTestProvider(t, "http://second", second))
+	c.providers[0].latency, c.providers[1].latency = time.Millisecond, 2*time.Millisecond
+
+	err := c.pinned("scan", func(client scanClient) error {
+		if _, err := client.HeaderByNumber(context.Background(), big.NewInt(100)); err != nil {
+			return err
+		}
+		// provider is rate limited in the middle of the scan
+		limited.Lock()
+		limited.err = errors.New("429 too many requests")
+		limited.Unlock()
+		_, err := client.FilterLogs(context.Background(), ethereum.FilterQuery{})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if c.providers[0].healthy {
+		t.Fatal("rate limited provider is still healthy")
+	}
+	// the whole scan is repeated on the next provider, so its results are consistent
+	if second.count("eth_getBlockByNumber") != 1 || second.count("eth_getLogs") != 1 {
+		t.Fatalf("second provider got %v calls, want header and logs", second.calls)
+	}
+	if limited.count("eth_getLogs") != 1 {
+		t.Fatalf("limited provider got %v calls", limited.calls)
+	}
+}
+
+func TestIsProviderError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"no error", nil, false},
+		{"not found", ethereum.NotFound, false},
+		{"chain reorganised", fmt.Errorf("log of block 10: %w", errChainReorganised), false},
+		{"transport error", errors.New("connection refused"), true},
+		{"client closed", rpc.ErrClientQuit, true},
+		{"timeout", context.DeadlineExceeded, true},
+		{"node error", &rpcError{"execution reverted"}, false},
+		{"nonce too low", &rpcError{"nonce too low"}, false},
+		{"rate limited", &rpcError{"daily request rate limit reached"}, true},
+		{"too many requests", &rpcError{"Too Many Requests"}, true},
+		{"header not found", &rpcError{"header not found"}, true},
+		{"missing trie node", &rpcError{"missing trie node 0x1"}, true},
+		{"revert with data", &revertError{data: "0x08c379a0"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isProviderError(tt.err); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSendTransactionAlreadyKnown(t *testing.T) {
+	tx := types.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(0), 21000, big.NewInt(1), nil)
+
+	tests := []struct {
+		name    string
+		first   *provider
+		wantErr bool
+	}{
+		{"after failed attempt", newClosedProvider(t, "http://first"), false},
+		{"on the first attempt", newTestProvider(t, "http://first", newFakeProvider(100, errors.New("already known"))), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newTestMultiClient(tt.first, newTestProvider(t, "http://second", newFakeProvider(100, errors.New("already known"))))
+			tt.first.latency, c.providers[1].latency = time.Millisecond, 2*time.Millisecond
+
+			err := c.SendTransaction(context.Background(), tx)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("got err %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// rpcError is error returned by the node
+type rpcError struct {
+	message string
+}
+
+func (e *rpcError) Error() string  { return e.message }
+func (e *rpcError) ErrorCode() int { return -32000 }
@@ -0,0 +1,59 @@
+package eth
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// errUnknownEvent is returned for bridge contract log with event id(topic0) not watched by the service
+var errUnknownEvent = errors.New("unknown event")
+
+// quarantine keeps raw log failed parsing
+func (w *Erc20Worker) quarantine(log *types.Log, err error) *storage.QuarantinedLog {
+	topics := make([]string, 0, len(log.Topics))
+	for _, topic := range log.Topics {
+		topics = append(topics, topic.Hex())
+	}
+
+	return &storage.QuarantinedLog{
+		Chain:     w.chainName,
+		Contract:  log.Address.Hex(),
+		TxHash:    log.TxHash.Hex(),
+		LogIndex:  log.Index,
+		BlockHash: log.BlockHash.Hex(),
+		Height:    int64(log.BlockNumber),
+		Topics:    strings.Join(topics, ","),
+		Data:      hexutil.Encode(log.Data),
+		Error:     err.Error(),
+	}
+}
+
+// ReparseLog parses quarantined log again, nil is returned if log is not watched by the service
+func (w *Erc20Worker) ReparseLog(quarantinedLog *storage.QuarantinedLog) (*storage.TxLog, error) {
+	data, err := hexutil.Decode(quarantinedLog.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &types.Log{
+		Address:     common.HexToAddress(quarantinedLog.Contract),
+		Data:        data,
+		BlockNumber: uint64(quarantinedLog.Height),
+		TxHash:      common.HexToHash(quarantinedLog.TxHash),
+		BlockHash:   common.HexToHash(quarantinedLog.BlockHash),
+		Index:       quarantinedLog.LogIndex,
+	}
+	if quarantinedLog.Topics != "" {
+		for _, topic := range strings.Split(quarantinedLog.Topics, ",") {
+			log.Topics = append(log.Topics, common.HexToHash(topic))
+		}
+	}
+
+	return w.toTxLog(log)
+}
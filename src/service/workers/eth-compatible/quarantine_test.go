@@ -0,0 +1,181 @@
+package eth
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func newTestEventsWorker(t *testing.T) *Erc20Worker {
+	events, err := eventIDs(ethBr.EthBrABI, watchedEvents("ETH"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ignoredEvents, err := ignoredEventIDs(ethBr.EthBrABI, events)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Erc20Worker{
+		chainName:     "ETH",
+		logger:        testLogger(),
+		contractAddr:  common.HexToAddress("0xb1"),
+		events:        events,
+		ignoredEvents: ignoredEvents,
+	}
+}
+
+func TestToTxLogsQuarantine(t *testing.T) {
+	w := newTestEventsWorker(t)
+	bridgeABI, err := abi.JSON(strings.NewReader(ethBr.EthBrABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	deposit := bridgeABI.Events[DepositEventName]
+	data, err := deposit.Inputs.NonIndexed().Pack([8]byte{0, 0, 0, 0, 0, 0, 0, 1}, common.HexToAddress("0xd1"),
+		common.HexToAddress("0xe1"), common.HexToAddress("0xf1"), big.NewInt(1000), [32]byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	depositTopics := []common.Hash{deposit.ID, common.HexToHash("0x04"), common.HexToHash("0x0a"), common.HexToHash("0x07")}
+
+	tests := []struct {
+		name           string
+		log            types.Log
+		wantTxs        int
+		wantQuarantine int
+	}{
+		{"deposit", types.Log{Address: w.contractAddr, Topics: depositTopics, Data: data}, 1, 0},
+		{"deposit with truncated data", types.Log{Address: w.contractAddr, Topics: depositTopics, Data: data[:64]}, 0, 1},
+		{"event missing in ABI", types.Log{Address: w.contractAddr, Topics: []common.Hash{common.HexToHash("0xbad")}}, 0, 1},
+		{"anonymous event", types.Log{Address: w.contractAddr, Data: data}, 0, 1},
+		{"event not watched", types.Log{Address: w.contractAddr, Topics: []common.Hash{bridgeABI.Events[ProposalEventName].ID}}, 0, 0},
+		{"unknown event of handler", types.Log{Address: common.HexToAddress("0xc1"), Topics: []common.Hash{common.HexToHash("0xbad")}}, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txLogs, quarantined := w.toTxLogs([]types.Log{tt.log})
+			if len(txLogs) != tt.wantTxs || len(quarantined) != tt.wantQuarantine {
+				t.Fatalf("got %d txs and %d quarantined logs, want %d and %d", len(txLogs), len(quarantined), tt.wantTxs, tt.wantQuarantine)
+			}
+			for _, log := range quarantined {
+				if log.Chain != "ETH" || log.Error == "" || log.Contract != tt.log.Address.Hex() {
+					t.Fatalf("quarantined log %+v", log)
+				}
+			}
+		})
+	}
+
+	txLogs, _ := w.toTxLogs([]types.Log{{Address: w.contractAddr, Topics: depositTopics, Data: data}})
+	if txLogs[0].DepositNonce != 7 || txLogs[0].SenderAddr != common.HexToAddress("0xd1").Hex() || txLogs[0].InAmount != "1000" {
+		t.Fatalf("deposit is parsed as %+v", txLogs[0])
+	}
+}
+
+func TestReparseLog(t *testing.T) {
+	w := newTestEventsWorker(t)
+	quarantined := w.quarantine(&types.Log{Address: w.contractAddr, Topics: []common.Hash{common.HexToHash("0xbad")},
+		Data: []byte{1}, BlockNumber: 10, Index: 2}, errUnknownEvent)
+
+	if _, err := w.ReparseLog(quarantined); err == nil {
+		t.Fatal("want error for event still missing in ABI")
+	}
+
+	// event is known after ABI update
+	w.ignoredEvents[common.HexToHash("0xbad")] = "Upgraded"
+	txLog, err := w.ReparseLog(quarantined)
+	if err != nil || txLog != nil {
+		t.Fatalf("got %v, %v, want ignored log", txLog, err)
+	}
+}
+
+type filterArgs struct {
+	Address []common.Address `json:"address"`
+	Topics  [][]common.Hash  `json:"topics"`
+}
+
+// fakeLogsChain answers 'eth_getLogs' with its logs of queried addresses and keeps queries
+type fakeLogsChain struct {
+	logs    []types.Log
+	queries []filterArgs
+}
+
+func (c *fakeLogsChain) GetLogs(args filterArgs) ([]types.Log, error) {
+	c.queries = append(c.queries, args)
+	logs := make([]types.Log, 0)
+	for _, log := range c.logs {
+		for _, address := range args.Address {
+			if log.Address == address {
+				logs = append(logs, log)
+			}
+		}
+	}
+	return logs, nil
+}
+
+func TestGetLogs(t *testing.T) {
+	bridgeABI, err := abi.JSON(strings.NewReader(ethBr.EthBrABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := common.HexToAddress("0xc1")
+	owner := common.HexToAddress("0xa1").Hash()
+	ownershipID := bridgeABI.Events[OwnershipTransferredEventName].ID
+	logs := []types.Log{
+		{Address: common.HexToAddress("0xb1"), Topics: []common.Hash{bridgeABI.Events[PausedEventName].ID}, Data: owner.Bytes(), BlockNumber: 12},
+		{Address: common.HexToAddress("0xb1"), Topics: []common.Hash{common.HexToHash("0xbad")}, BlockNumber: 11},
+		{Address: common.HexToAddress("0xb1"), Topics: []common.Hash{bridgeABI.Events[ProposalEventName].ID}, BlockNumber: 11},
+		{Address: handler, Topics: []common.Hash{ownershipID, {}, owner}, BlockNumber: 11, Index: 1},
+	}
+
+	tests := []struct {
+		name           string
+		handlers       []common.Address
+		wantQueries    int
+		wantTxs        []storage.TxType
+		wantQuarantine int
+	}{
+		{"bridge only", nil, 1, []storage.TxType{storage.TxTypePaused}, 1},
+		{"with handlers", []common.Address{handler}, 2, []storage.TxType{storage.TxTypeOwnershipTransferred, storage.TxTypePaused}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := &fakeLogsChain{logs: logs}
+			w := newTestEventsWorker(t)
+			w.handlers = tt.handlers
+
+			txLogs, quarantined, err := w.getLogs(newTestClient(t, chain), 10, 20)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(chain.queries) != tt.wantQueries {
+				t.Fatalf("got %d queries, want %d", len(chain.queries), tt.wantQueries)
+			}
+			// bridge logs are taken by address only, topics filter is kept for handlers
+			if len(chain.queries[0].Address) != 1 || chain.queries[0].Address[0] != w.contractAddr || len(chain.queries[0].Topics) != 0 {
+				t.Fatalf("bridge query %+v", chain.queries[0])
+			}
+			if len(chain.queries) > 1 && (len(chain.queries[1].Topics) != 1 || len(chain.queries[1].Topics[0]) != len(w.events)) {
+				t.Fatalf("handler query %+v", chain.queries[1])
+			}
+
+			if len(txLogs) != len(tt.wantTxs) || len(quarantined) != tt.wantQuarantine {
+				t.Fatalf("got %d txs and %d quarantined logs, want %d and %d", len(txLogs), len(quarantined), len(tt.wantTxs), tt.wantQuarantine)
+			}
+			for i, txLog := range txLogs {
+				if txLog.TxType != tt.wantTxs[i] {
+					t.Fatalf("tx %d is %s, want %s", i, txLog.TxType, tt.wantTxs[i])
+				}
+			}
+		})
+	}
+}
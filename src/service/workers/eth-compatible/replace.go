@@ -0,0 +1,161 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// minGasPriceBump is the minimal percent of gas price increase accepted by nodes for replacement
+const minGasPriceBump = 10
+
+// ReplaceTx re-signs pending tx with the same nonce and bumped gas price and broadcasts it,
+// tx is taken from signed txs of the worker, so it is replaced even if nodes have dropped it
+func (w *Erc20Worker) ReplaceTx(hash string) (string, error) {
+	tx, err := w.getSignedTx(hash)
+	if err != nil {
+		return "", err
+	}
+	if receipt, err := w.client.TransactionReceipt(context.Background(), tx.Hash()); err == nil && receipt != nil {
+		return "", fmt.Errorf("tx %s is already mined", hash)
+	}
+
+	var txData types.TxData
+	if tx.Type() == types.DynamicFeeTxType {
+		feeCap, tipCap, err := w.dynamicFees()
+		if err != nil {
+			return "", err
+		}
+		if feeCap, err = w.bumpGasPrice(tx.GasFeeCap(), feeCap, w.config.MaxFeePerGas); err != nil {
+			return "", err
+		}
+		if tipCap, err = w.bumpGasPrice(tx.GasTipCap(), tipCap, feeCap); err != nil {
+			return "", err
+		}
+		txData = &types.DynamicFeeTx{
+			ChainID:   big.NewInt(w.chainID),
+			Nonce:     tx.Nonce(),
+			GasTipCap: tipCap,
+			GasFeeCap: feeCap,
+			Gas:       tx.Gas(),
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Data:      tx.Data(),
+		}
+	} else {
+		gasPrice, err := w.bumpGasPrice(tx.GasPrice(), w.legacyGasPrice(), w.config.MaxGasPrice)
+		if err != nil {
+			return "", err
+		}
+		txData = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: gasPrice,
+			Gas:      tx.Gas(),
+			To:       tx.To(),
+			Value:    tx.Value(),
+			Data:     tx.Data(),
+		}
+	}
+
+	signedTx, err := w.signer.SignTx(types.NewTx(txData), big.NewInt(w.chainID))
+	if err != nil {
+		return "", err
+	}
+	if err := w.client.SendTransaction(context.Background(), signedTx); err != nil {
+		return "", err
+	}
+	w.saveSignedTx(signedTx)
+
+	w.logger.Infof("tx %s replaced by %s, nonce = %d", hash, signedTx.Hash().Hex(), tx.Nonce())
+	return signedTx.Hash().Hex(), nil
+}
+
+// IsTxNonceUsed returns true if nonce of the tx is taken by a tx mined and confirmed on chain,
+// so neither the tx nor its replacements can be mined anymore
+func (w *Erc20Worker) IsTxNonceUsed(hash string) (bool, error) {
+	tx, err := w.getSignedTx(hash)
+	if err != nil {
+		return false, err
+	}
+	head, err := w.GetHeight()
+	if err != nil {
+		return false, err
+	}
+	confirmed := head - w.GetConfirmNum()
+	if confirmed < 0 {
+		confirmed = 0
+	}
+	nonce, err := w.client.NonceAt(context.Background(), w.config.WorkerAddr, big.NewInt(confirmed))
+	if err != nil {
+		return false, err
+	}
+	return nonce > tx.Nonce(), nil
+}
+
+// saveSignedTx keeps raw tx to replace it later
+func (w *Erc20Worker) saveSignedTx(tx *types.Transaction) {
+	raw, err := tx.MarshalBinary()
+	if err == nil {
+		err = w.storage.SaveSignedTx(&storage.SignedTx{
+			Chain:  w.chainName,
+			TxHash: tx.Hash().Hex(),
+			Nonce:  tx.Nonce(),
+			RawTx:  hexutil.Encode(raw),
+		})
+	}
+	if err != nil {
+		w.logger.Errorf("save signed tx %s error, err = %v", tx.Hash().Hex(), err)
+	}
+}
+
+// getSignedTx returns tx signed by the worker, txs signed before they were kept are taken from chain
+func (w *Erc20Worker) getSignedTx(hash string) (*types.Transaction, error) {
+	signedTx, err := w.storage.GetSignedTx(w.chainName, hash)
+	if err != nil {
+		tx, _, err := w.client.TransactionByHash(context.Background(), common.HexToHash(hash))
+		if err != nil {
+			return nil, fmt.Errorf("get tx %s error, err=%w", hash, err)
+		}
+		return tx, nil
+	}
+
+	raw, err := hexutil.Decode(signedTx.RawTx)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	return tx, nil
+}
+
+// bumpGasPrice returns price increased by gas_price_bump percent (at least by 10%),
+// not lower than the market price and not higher than the cap
+func (w *Erc20Worker) bumpGasPrice(price, market, cap *big.Int) (*big.Int, error) {
+	bump := w.config.GasPriceBump
+	if bump < minGasPriceBump {
+		bump = minGasPriceBump
+	}
+
+	minPrice := new(big.Int).Mul(price, big.NewInt(100+bump))
+	minPrice.Div(minPrice, big.NewInt(100))
+
+	bumped := minPrice
+	if market != nil && market.Cmp(bumped) > 0 {
+		bumped = new(big.Int).Set(market)
+	}
+	if cap != nil && cap.Sign() > 0 && bumped.Cmp(cap) > 0 {
+		bumped = new(big.Int).Set(cap)
+	}
+	if bumped.Cmp(minPrice) < 0 {
+		return nil, fmt.Errorf("gas price cap %s reached for chain %s", cap, w.chainName)
+	}
+	return bumped, nil
+}
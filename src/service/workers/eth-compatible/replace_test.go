@@ -0,0 +1,56 @@
+package eth
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+)
+
+func TestBumpGasPrice(t *testing.T) {
+	tests := []struct {
+		name    string
+		bump    int64
+		price   int64
+		market  int64
+		cap     int64
+		want    int64
+		wantErr bool
+	}{
+		{"at least 10 percent", 0, 100, 0, 0, 110, false},
+		{"configured bump", 25, 100, 0, 0, 125, false},
+		{"bump below minimum", 5, 100, 0, 0, 110, false},
+		{"market price is higher", 10, 100, 150, 0, 150, false},
+		{"market price is lower", 10, 100, 90, 0, 110, false},
+		{"capped", 10, 100, 300, 200, 200, false},
+		{"cap reached", 10, 100, 0, 105, 0, true},
+		{"cap equal to minimal bump", 10, 100, 0, 110, 110, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Erc20Worker{chainName: "ETH", config: &models.WorkerConfig{GasPriceBump: tt.bump}}
+			var market, cap *big.Int
+			if tt.market > 0 {
+				market = big.NewInt(tt.market)
+			}
+			if tt.cap > 0 {
+				cap = big.NewInt(tt.cap)
+			}
+
+			got, err := w.bumpGasPrice(big.NewInt(tt.price), market, cap)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Int64() != tt.want {
+				t.Fatalf("got %s, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package eth
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// panicSelector is selector of Panic(uint256) raised by failed assert, overflow and etc
+var panicSelector = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+
+// bridge ABIs are used to pack calls of bridge contracts and decode their custom errors
+var (
+	laBridgeABI  = mustParseABI(laBr.LaBrABI)
+	ethBridgeABI = mustParseABI(ethBr.EthBrABI)
+	bridgeABIs   = []abi.ABI{laBridgeABI, ethBridgeABI}
+)
+
+// mustParseABI panics on invalid definition, bindings are generated so it is a build error
+func mustParseABI(definition string) abi.ABI {
+	contractABI, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid contract ABI: %v", err))
+	}
+	return contractABI
+}
+
+// GetRevertReason replays failed tx from its sender with its gas and value on the state
+// before the block of its receipt and returns decoded revert reason
+func (w *Erc20Worker) GetRevertReason(hash string) (string, error) {
+	tx, _, err := w.client.TransactionByHash(context.Background(), common.HexToHash(hash))
+	if err != nil {
+		return "", err
+	}
+	receipt, err := w.client.TransactionReceipt(context.Background(), common.HexToHash(hash))
+	if err != nil {
+		return "", err
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(big.NewInt(w.chainID)), tx)
+	if err != nil {
+		return "", err
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err = w.client.CallContract(context.Background(), msg, new(big.Int).Sub(receipt.BlockNumber, big.NewInt(1)))
+	if err == nil {
+		// replay succeeds when state of the block differs from the one tx was executed on
+		return "reverted, reason is unknown", nil
+	}
+	if reason, ok := revertReason(err); ok {
+		return reason, nil
+	}
+	return err.Error(), nil
+}
+
+// revertReason returns decoded revert reason if err is a revert of eth_call
+func revertReason(err error) (string, bool) {
+	var dataErr rpc.DataError
+	if errors.As(err, &dataErr) {
+		if data, ok := dataErr.ErrorData().(string); ok && len(common.FromHex(data)) > 0 {
+			return decodeRevert(common.FromHex(data)), true
+		}
+	}
+	if strings.Contains(strings.ToLower(err.Error()), "revert") {
+		return err.Error(), true
+	}
+	return "", false
+}
+
+// decodeRevert decodes Error(string), Panic(uint256) or custom error of bridge contracts,
+// returns hex of data when it is unknown
+func decodeRevert(data []byte) string {
+	if reason, err := abi.UnpackRevert(data); err == nil {
+		return reason
+	}
+	if len(data) < 4 {
+		return hexutil.Encode(data)
+	}
+	if bytes.Equal(data[:4], panicSelector) {
+		return fmt.Sprintf("panic: 0x%x", new(big.Int).SetBytes(data[4:]))
+	}
+	for _, contractABI := range bridgeABIs {
+		for _, abiErr := range contractABI.Errors {
+			if !bytes.Equal(data[:4], abiErr.ID[:4]) {
+				continue
+			}
+			args, err := abiErr.Unpack(data)
+			if err != nil {
+				return abiErr.Name
+			}
+			return fmt.Sprintf("%s%v", abiErr.Name, args)
+		}
+	}
+	return hexutil.Encode(data)
+}
@@ -0,0 +1,140 @@
+package eth
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const testErrorsABI = `[{"inputs":[{"internalType":"uint64","name":"depositNonce","type":"uint64"}],"name":"ProposalAlreadyExecuted","type":"error"}]`
+
+func revertData(t *testing.T, signature string, types []string, values ...interface{}) []byte {
+	args := make(abi.Arguments, 0, len(types))
+	for _, name := range types {
+		typ, err := abi.NewType(name, "", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args = append(args, abi.Argument{Type: typ})
+	}
+	packed, err := args.Pack(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return append(crypto.Keccak256([]byte(signature))[:4], packed...)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	errorsABI, err := abi.JSON(strings.NewReader(testErrorsABI))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(abis []abi.ABI) { bridgeABIs = abis }(bridgeABIs)
+	bridgeABIs = append(bridgeABIs, errorsABI)
+
+	tests := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{"error", revertData(t, "Error(string)", []string{"string"}, "relayer already voted"), "relayer already voted"},
+		{"panic", revertData(t, "Panic(uint256)", []string{"uint256"}, big.NewInt(0x11)), "panic: 0x11"},
+		{"custom error", revertData(t, "ProposalAlreadyExecuted(uint64)", []string{"uint64"}, uint64(42)), "ProposalAlreadyExecuted[42]"},
+		{"custom error with invalid args", crypto.Keccak256([]byte("ProposalAlreadyExecuted(uint64)"))[:4], "ProposalAlreadyExecuted"},
+		{"unknown selector", common.FromHex("0xdeadbeef01"), "0xdeadbeef01"},
+		{"short data", common.FromHex("0x01"), "0x01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := decodeRevert(tt.data); got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+type revertError struct {
+	data string
+}
+
+func (e *revertError) Error() string          { return "execution reverted" }
+func (e *revertError) ErrorCode() int         { return 3 }
+func (e *revertError) ErrorData() interface{} { return e.data }
+
+type replayArgs struct {
+	From  common.Address `json:"from"`
+	Gas   hexutil.Uint64 `json:"gas"`
+	Value *hexutil.Big   `json:"value"`
+}
+
+// fakeReplayChain serves failed tx with its receipt and records how it is replayed
+type fakeReplayChain struct {
+	tx      *types.Transaction
+	receipt *types.Receipt
+	revert  []byte
+	args    replayArgs
+	block   string
+}
+
+func (c *fakeReplayChain) GetTransactionByHash(hash common.Hash) (json.RawMessage, error) {
+	return c.tx.MarshalJSON()
+}
+
+func (c *fakeReplayChain) GetTransactionReceipt(hash common.Hash) (*types.Receipt, error) {
+	return c.receipt, nil
+}
+
+func (c *fakeReplayChain) Call(args replayArgs, block string) (hexutil.Bytes, error) {
+	c.args, c.block = args, block
+	return nil, &revertError{data: hexutil.Encode(c.revert)}
+}
+
+func TestGetRevertReason(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1")
+	tx, err := types.SignTx(types.NewTransaction(3, to, big.NewInt(1000), 210000, big.NewInt(1), []byte{1, 2, 3, 4}),
+		types.LatestSignerForChainID(big.NewInt(5)), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chain := &fakeReplayChain{
+		tx:      tx,
+		receipt: &types.Receipt{Status: types.ReceiptStatusFailed, TxHash: tx.Hash(), BlockNumber: big.NewInt(100), Logs: []*types.Log{}},
+		revert:  revertData(t, "Error(string)", []string{"string"}, "proposal already executed"),
+	}
+
+	w := &Erc20Worker{
+		chainName: "ETH",
+		chainID:   5,
+		config:    &models.WorkerConfig{},
+		client:    newTestClient(t, chain),
+	}
+
+	reason, err := w.GetRevertReason(tx.Hash().Hex())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reason != "proposal already executed" {
+		t.Fatalf("got reason %q", reason)
+	}
+	if chain.block != "0x63" {
+		t.Fatalf("replayed at block %s, want 0x63", chain.block)
+	}
+	if chain.args.From != crypto.PubkeyToAddress(key.PublicKey) || uint64(chain.args.Gas) != tx.Gas() ||
+		chain.args.Value.ToInt().Cmp(tx.Value()) != 0 {
+		t.Fatalf("replayed with from %s, gas %d, value %s", chain.args.From.Hex(), chain.args.Gas, chain.args.Value)
+	}
+}
@@ -0,0 +1,158 @@
+package eth
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// blocks requested by the first eth_getLogs
+	defaultScanWindow = 100
+	// blocks requested by one eth_getLogs at most if provider limit is not configured
+	defaultMaxBlockRange = 2000
+	// catch-up progress is logged when chain is ahead more than this number of blocks
+	catchUpThreshold = 100
+)
+
+// scanWindow is adaptive number of blocks requested by one eth_getLogs: it grows while
+// requests succeed and shrinks when provider rejects the range as too large or times out
+type scanWindow struct {
+	sync.Mutex
+	size   int64
+	max    int64
+	logger *logrus.Entry
+
+	cursor int64
+	head   int64
+	// cursor and time when catch-up has started, to measure scan rate
+	startCursor int64
+	startedAt   time.Time
+}
+
+func newScanWindow(logger *logrus.Entry, maxBlockRange int64) *scanWindow {
+	if maxBlockRange <= 0 {
+		maxBlockRange = defaultMaxBlockRange
+	}
+	size := int64(defaultScanWindow)
+	if size > maxBlockRange {
+		size = maxBlockRange
+	}
+	return &scanWindow{
+		size:   size,
+		max:    maxBlockRange,
+		logger: logger.WithField("layer", "scan"),
+	}
+}
+
+// next returns the last block of the range starting after height
+func (s *scanWindow) next(height, head int64) int64 {
+	s.Lock()
+	defer s.Unlock()
+
+	if head-height > s.size {
+		return height + s.size
+	}
+	return head
+}
+
+// grow doubles window after successful request
+func (s *scanWindow) grow() {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.size *= 2; s.size > s.max {
+		s.size = s.max
+	}
+}
+
+// shrink halves window after request failed because of its range,
+// returns false if window can not be smaller
+func (s *scanWindow) shrink(err error) bool {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.size == 1 {
+		return false
+	}
+	s.size /= 2
+	s.logger.Warnf("shrink scan window to %d blocks, err = %v", s.size, err)
+	return true
+}
+
+// progress records scanned cursor and head of the chain, logs catch-up progress
+func (s *scanWindow) progress(cursor, head int64) {
+	s.Lock()
+	defer s.Unlock()
+
+	if head-cursor <= catchUpThreshold {
+		if !s.startedAt.IsZero() {
+			s.logger.Infof("caught up with the chain at %d in %s", cursor, time.Since(s.startedAt).Round(time.Second))
+		}
+		s.startedAt = time.Time{}
+	} else if s.startedAt.IsZero() {
+		s.startCursor, s.startedAt = s.cursor, time.Now()
+		if s.startCursor == 0 {
+			s.startCursor = cursor
+		}
+	}
+	s.cursor, s.head = cursor, head
+
+	if !s.startedAt.IsZero() {
+		status := s.status()
+		s.logger.Infof("catching up: block %d of %d, %d behind, window = %d, rate = %.1f blocks/s, eta = %ds",
+			cursor, head, status.Behind, status.Window, status.Rate, status.ETA)
+	}
+}
+
+// status must be called under lock
+func (s *scanWindow) status() models.ScanStatus {
+	status := models.ScanStatus{
+		Window: s.size,
+		Behind: s.head - s.cursor,
+	}
+	if !s.startedAt.IsZero() {
+		if elapsed := time.Since(s.startedAt).Seconds(); elapsed > 0 {
+			status.Rate = float64(s.cursor-s.startCursor) / elapsed
+		}
+		if status.Rate > 0 {
+			status.ETA = int64(float64(status.Behind) / status.Rate)
+		}
+	}
+	return status
+}
+
+// getStatus ...
+func (s *scanWindow) getStatus() models.ScanStatus {
+	s.Lock()
+	defer s.Unlock()
+	return s.status()
+}
+
+// isRangeError returns true if eth_getLogs failed because of too many blocks or results requested,
+// rate limit is not a range error, it is handled by failing over to another provider
+func isRangeError(err error) bool {
+	normalizedErr := strings.ToLower(err.Error())
+	if strings.Contains(normalizedErr, "rate limit") || strings.Contains(normalizedErr, "too many requests") {
+		return false
+	}
+	for _, msg := range []string{
+		"more than",
+		"too many",
+		"too large",
+		"exceed",
+		"block range",
+		"timeout",
+		"timed out",
+		"deadline exceeded",
+	} {
+		if strings.Contains(normalizedErr, msg) {
+			return true
+		}
+	}
+	return false
+}
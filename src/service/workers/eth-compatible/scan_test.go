@@ -0,0 +1,135 @@
+package eth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+func TestScanWindow(t *testing.T) {
+	s := newScanWindow(testLogger(), 400)
+	steps := []struct {
+		name     string
+		action   string
+		height   int64
+		head     int64
+		want     int64
+		shrunken bool
+	}{
+		{"initial window", "", 1000, 5000, 1100, false},
+		{"chain is closer than window", "", 1000, 1050, 1050, false},
+		{"grown", "grow", 1000, 5000, 1200, false},
+		{"grown to max", "grow", 1000, 5000, 1400, false},
+		{"max is not exceeded", "grow", 1000, 5000, 1400, false},
+		{"shrunk", "shrink", 1000, 5000, 1200, true},
+		{"shrunk again", "shrink", 1000, 5000, 1100, true},
+	}
+
+	for _, step := range steps {
+		switch step.action {
+		case "grow":
+			s.grow()
+		case "shrink":
+			if !s.shrink(errors.New("query returned more than 10000 results")) {
+				t.Fatalf("%s: window is not shrunk", step.name)
+			}
+		}
+		if got := s.next(step.height, step.head); got != step.want {
+			t.Fatalf("%s: next %d, want %d", step.name, got, step.want)
+		}
+	}
+
+	for s.shrink(errors.New("block range is too large")) {
+	}
+	if got := s.next(1000, 5000); got != 1001 {
+		t.Fatalf("window of one block, next %d, want 1001", got)
+	}
+	if s.shrink(errors.New("block range is too large")) {
+		t.Fatal("window is shrunk below one block")
+	}
+}
+
+func TestNewScanWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxBlockRange int64
+		wantSize      int64
+		wantMax       int64
+	}{
+		{"default max", 0, defaultScanWindow, defaultMaxBlockRange},
+		{"max below default window", 10, 10, 10},
+		{"configured max", 5000, defaultScanWindow, 5000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newScanWindow(testLogger(), tt.maxBlockRange)
+			if s.size != tt.wantSize || s.max != tt.wantMax {
+				t.Fatalf("got size %d, max %d, want %d, %d", s.size, s.max, tt.wantSize, tt.wantMax)
+			}
+		})
+	}
+}
+
+func TestIsRangeError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{errors.New("query returned more than 10000 results"), true},
+		{errors.New("exceed maximum block range: 5000"), true},
+		{errors.New("Log response size exceeded"), true},
+		{errors.New("block range is too large"), true},
+		{errors.New("request timed out"), true},
+		{fmt.Errorf("get logs: %w", context.DeadlineExceeded), true},
+		{errors.New("too many requests"), false},
+		{errors.New("daily request rate limit reached"), false},
+		{errors.New("header not found"), false},
+		{errors.New("connection refused"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := isRangeError(tt.err); got != tt.want {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// fakeHeaders answers 'eth_getBlockByNumber' with headers of blocks timed 1000 seconds apart
+type fakeHeaders struct {
+	requested []uint64
+}
+
+func (c *fakeHeaders) GetBlockByNumber(number hexutil.Uint64, fullTx bool) (*Header, error) {
+	c.requested = append(c.requested, uint64(number))
+	return &Header{Number: number, Time: number * 1000}, nil
+}
+
+func TestSetLogsTime(t *testing.T) {
+	chain := &fakeHeaders{}
+	client := newTestClient(t, chain)
+	header := &Header{Number: 20, Time: 20500}
+	logs := []*storage.TxLog{{Height: 12}, {Height: 20}, {Height: 12}, {Height: 15}}
+
+	if err := setLogsTime(client, header, logs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int64{12000, 20500, 12000, 15000}
+	for i, log := range logs {
+		if log.Timestamp != want[i] {
+			t.Errorf("log %d at %d: got timestamp %d, want %d", i, log.Height, log.Timestamp, want[i])
+		}
+	}
+	// header of the scanned block is known, others are fetched once per block
+	if len(chain.requested) != 2 || chain.requested[0] != 12 || chain.requested[1] != 15 {
+		t.Fatalf("requested headers %v, want [12 15]", chain.requested)
+	}
+}
@@ -0,0 +1,154 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	PausedEventName               = "Paused"
+	UnpausedEventName             = "Unpaused"
+	OwnershipTransferredEventName = "OwnershipTransferred"
+)
+
+// SecurityEvent represents Paused, Unpaused and OwnershipTransferred events of bridge and handler contracts
+type SecurityEvent struct {
+	Contract common.Address
+	TxType   storage.TxType
+	// Account is who paused/unpaused contract or the new owner
+	Account       common.Address
+	PreviousOwner common.Address
+}
+
+// ParsePausedEvent parses Paused and Unpaused events
+func ParsePausedEvent(abi *abi.ABI, log *types.Log, name string) (ContractEvent, error) {
+	var paused struct {
+		Account common.Address
+	}
+	if err := abi.UnpackIntoInterface(&paused, name, log.Data); err != nil {
+		return nil, err
+	}
+
+	ev := SecurityEvent{
+		Contract: log.Address,
+		TxType:   storage.TxTypePaused,
+		Account:  paused.Account,
+	}
+	if name == UnpausedEventName {
+		ev.TxType = storage.TxTypeUnpaused
+	}
+	fmt.Printf("[%s] %s by %s\n", log.Address.Hex(), name, ev.Account.Hex())
+
+	return ev, nil
+}
+
+// ParseOwnershipTransferredEvent ...
+func ParseOwnershipTransferredEvent(log *types.Log) (ContractEvent, error) {
+	if len(log.Topics) != 3 {
+		return nil, fmt.Errorf("%s event must have 3 topics, got %d", OwnershipTransferredEventName, len(log.Topics))
+	}
+
+	ev := SecurityEvent{
+		Contract:      log.Address,
+		TxType:        storage.TxTypeOwnershipTransferred,
+		PreviousOwner: common.BytesToAddress(log.Topics[1].Bytes()),
+		Account:       common.BytesToAddress(log.Topics[2].Bytes()),
+	}
+	fmt.Printf("[%s] OwnershipTransferred from %s to %s\n", log.Address.Hex(), ev.PreviousOwner.Hex(), ev.Account.Hex())
+
+	return ev, nil
+}
+
+// ToTxLog ...
+func (ev SecurityEvent) ToTxLog(chain string) *storage.TxLog {
+	txLog := &storage.TxLog{
+		Chain:        chain,
+		TxType:       ev.TxType,
+		SenderAddr:   ev.Contract.Hex(),
+		ReceiverAddr: ev.Account.Hex(),
+	}
+	if ev.TxType == storage.TxTypeOwnershipTransferred {
+		txLog.Data = ev.PreviousOwner.Hex()
+	}
+	return txLog
+}
+
+// getHandlers returns addresses of handler contracts of known resource ids registered in bridge contract
+func (w *Erc20Worker) getHandlers() []common.Address {
+	handlers := make([]common.Address, 0)
+	known := make(map[common.Address]bool)
+	for _, rID := range w.storage.FetchResourceIDs() {
+		handlerAddr, err := w.getHandlerAddr(rID.ID)
+		if err != nil {
+			w.logger.Warnf("get handler of resource id %s error, err = %v", rID.Name, err)
+			continue
+		}
+
+		handler := common.HexToAddress(handlerAddr)
+		if handler == (common.Address{}) || known[handler] {
+			continue
+		}
+		known[handler] = true
+		handlers = append(handlers, handler)
+	}
+	return handlers
+}
+
+// GetContractState returns admin state of bridge contract, proxy implementation and owner are set on LA only
+func (w *Erc20Worker) GetContractState() (*storage.ContractState, error) {
+	callOpts := &bind.CallOpts{
+		From:    w.config.WorkerAddr,
+		Context: context.Background(),
+	}
+	state := &storage.ContractState{
+		Chain:    w.chainName,
+		Contract: w.contractAddr.Hex(),
+	}
+
+	if w.chainName == "LA" {
+		instance, err := laBr.NewLaBr(w.contractAddr, w.client)
+		if err != nil {
+			return nil, err
+		}
+		if state.Paused, err = instance.Paused(callOpts); err != nil {
+			return nil, err
+		}
+		owner, err := instance.Owner(callOpts)
+		if err != nil {
+			return nil, err
+		}
+		implementation, err := instance.Implementation(callOpts)
+		if err != nil {
+			return nil, err
+		}
+		proxyOwner, err := instance.ProxyOwner(callOpts)
+		if err != nil {
+			return nil, err
+		}
+		state.Owner, state.Implementation, state.ProxyOwner = owner.Hex(), implementation.Hex(), proxyOwner.Hex()
+		return state, nil
+	}
+
+	instance, err := ethBr.NewEthBr(w.contractAddr, w.client)
+	if err != nil {
+		return nil, err
+	}
+	if state.Paused, err = instance.Paused(callOpts); err != nil {
+		return nil, err
+	}
+	owner, err := instance.Owner(callOpts)
+	if err != nil {
+		return nil, err
+	}
+	state.Owner = owner.Hex()
+	return state, nil
+}
@@ -0,0 +1,87 @@
+package eth
+
+import (
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/service/storage"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestParsePausedEvent(t *testing.T) {
+	contract, account := common.HexToAddress("0xc1"), common.HexToAddress("0xa1")
+
+	tests := []struct {
+		name     string
+		event    string
+		data     []byte
+		wantType storage.TxType
+		wantErr  bool
+	}{
+		{"paused", PausedEventName, common.LeftPadBytes(account.Bytes(), 32), storage.TxTypePaused, false},
+		{"unpaused", UnpausedEventName, common.LeftPadBytes(account.Bytes(), 32), storage.TxTypeUnpaused, false},
+		{"truncated data", PausedEventName, account.Bytes(), "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, contractABI := range bridgeABIs {
+				log := &types.Log{Address: contract, Topics: []common.Hash{contractABI.Events[tt.event].ID}, Data: tt.data}
+				ev, err := ParsePausedEvent(&contractABI, log, tt.event)
+				if tt.wantErr {
+					if err == nil {
+						t.Fatalf("want error, got %+v", ev)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				txLog := ev.ToTxLog("LA")
+				if txLog.TxType != tt.wantType || txLog.Chain != "LA" || txLog.SenderAddr != contract.Hex() ||
+					txLog.ReceiverAddr != account.Hex() || txLog.Data != "" {
+					t.Fatalf("got tx log %+v", txLog)
+				}
+			}
+		})
+	}
+}
+
+func TestParseOwnershipTransferredEvent(t *testing.T) {
+	contract, previous, owner := common.HexToAddress("0xc1"), common.HexToAddress("0xa1"), common.HexToAddress("0xa2")
+	eventID := bridgeABIs[0].Events[OwnershipTransferredEventName].ID
+
+	tests := []struct {
+		name      string
+		topics    []common.Hash
+		wantOwner common.Address
+		wantErr   bool
+	}{
+		{"transferred", []common.Hash{eventID, previous.Hash(), owner.Hash()}, previous, false},
+		{"from zero address", []common.Hash{eventID, {}, owner.Hash()}, common.Address{}, false},
+		{"missing new owner", []common.Hash{eventID, previous.Hash()}, common.Address{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ev, err := ParseOwnershipTransferredEvent(&types.Log{Address: contract, Topics: tt.topics})
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %+v", ev)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			txLog := ev.ToTxLog("ETH")
+			if txLog.TxType != storage.TxTypeOwnershipTransferred || txLog.SenderAddr != contract.Hex() ||
+				txLog.ReceiverAddr != owner.Hex() || txLog.Data != tt.wantOwner.Hex() {
+				t.Fatalf("got tx log %+v", txLog)
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package eth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+const subscriptionBuffer = 128
+
+// SubscribeBlockAndTxs subscribes to new heads and bridge contract logs on websocket provider.
+// Block is sent into ch when the next head arrives, so logs of the block have been delivered before it.
+// Subscription drops on gap or reorg between heads and on a log of already sent block,
+// watcher backfills blocks by polling then
+func (w *Erc20Worker) SubscribeBlockAndTxs(ch chan<- *models.BlockAndTxLogs) (ethereum.Subscription, error) {
+	if !w.config.Subscribe {
+		return nil, workers.ErrSubscriptionNotSupported
+	}
+
+	p, err := w.client.subscriber()
+	if err != nil {
+		return nil, err
+	}
+
+	// heads are taken as raw json, hash computed from header fields is wrong on chains with non-ethereum headers
+	heads := make(chan *Header, subscriptionBuffer)
+	headSub, err := p.rpc.EthSubscribe(context.Background(), heads, "newHeads")
+	if err != nil {
+		return nil, err
+	}
+
+	// all logs of bridge contract and watched events of handlers come into one channel
+	logs := make(chan types.Log, subscriptionBuffer)
+	logSub, err := p.client.SubscribeFilterLogs(context.Background(), w.bridgeLogsQuery(nil, nil), logs)
+	if err != nil {
+		headSub.Unsubscribe()
+		return nil, err
+	}
+
+	var handlerLogSub ethereum.Subscription
+	var handlerLogErr <-chan error
+	if len(w.handlers) > 0 {
+		handlerLogSub, err = p.client.SubscribeFilterLogs(context.Background(), w.handlerLogsQuery(nil, nil), logs)
+		if err != nil {
+			headSub.Unsubscribe()
+			logSub.Unsubscribe()
+			return nil, err
+		}
+		handlerLogErr = handlerLogSub.Err()
+	}
+
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer headSub.Unsubscribe()
+		defer logSub.Unsubscribe()
+		if handlerLogSub != nil {
+			defer handlerLogSub.Unsubscribe()
+		}
+
+		var prev *Header
+		var sent int64
+		pending := make([]types.Log, 0)
+		for {
+			select {
+			case <-quit:
+				return nil
+			case err := <-headSub.Err():
+				return fmt.Errorf("new heads subscription dropped, err = %v", err)
+			case err := <-logSub.Err():
+				return fmt.Errorf("logs subscription dropped, err = %v", err)
+			case err := <-handlerLogErr:
+				return fmt.Errorf("handler logs subscription dropped, err = %v", err)
+			case log := <-logs:
+				if sent != 0 && int64(log.BlockNumber) <= sent {
+					return &workers.RescanError{Height: int64(log.BlockNumber)}
+				}
+				pending = addLog(pending, log)
+			case head := <-heads:
+				if prev != nil && (head.Number != prev.Number+1 || head.ParentHash != prev.Hash) {
+					return fmt.Errorf("heads are not contiguous, prev = %d(%s), new = %d(parent %s)",
+						prev.Number, prev.Hash.Hex(), head.Number, head.ParentHash.Hex())
+				}
+				if prev != nil {
+					var blockLogs []types.Log
+					blockLogs, pending = splitLogs(pending, uint64(prev.Number))
+					txLogs, quarantined := w.toTxLogs(blockLogs)
+					for _, txLog := range txLogs {
+						txLog.Timestamp = int64(prev.Time)
+					}
+					blockAndTxLogs := &models.BlockAndTxLogs{
+						Height:          int64(prev.Number),
+						BlockHash:       prev.Hash.Hex(),
+						ParentBlockHash: prev.ParentHash.Hex(),
+						BlockTime:       int64(prev.Time),
+						TxLogs:          txLogs,
+						QuarantinedLogs: quarantined,
+					}
+					select {
+					case ch <- blockAndTxLogs:
+					case <-quit:
+						return nil
+					}
+					sent = int64(prev.Number)
+				}
+				prev = head
+			}
+		}
+	}), nil
+}
+
+// splitLogs returns logs of block at height and logs of later blocks,
+// earlier logs came before the first head and belong to blocks scanned by polling
+func splitLogs(logs []types.Log, height uint64) (passed, rest []types.Log) {
+	for _, log := range logs {
+		if log.BlockNumber == height {
+			passed = append(passed, log)
+		} else if log.BlockNumber > height {
+			rest = append(rest, log)
+		}
+	}
+	return passed, rest
+}
+
+// addLog adds new log to pending ones or drops log removed by reorg
+func addLog(logs []types.Log, log types.Log) []types.Log {
+	if log.Removed {
+		return removeLog(logs, log)
+	}
+	return append(logs, log)
+}
+
+// removeLog drops log removed by reorg
+func removeLog(logs []types.Log, removed types.Log) []types.Log {
+	rest := logs[:0]
+	for _, log := range logs {
+		if log.TxHash != removed.TxHash || log.Index != removed.Index {
+			rest = append(rest, log)
+		}
+	}
+	return rest
+}
@@ -0,0 +1,103 @@
+package eth
+
+import (
+	"fmt"
+	"strings"
+
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// watchedEvents returns names of bridge contract events detected on the chain
+func watchedEvents(chainName string) []string {
+	if chainName == "LA" {
+		return []string{
+			DepositEventName,
+			ProposalEventName,
+			ProposalVoteEventName,
+			ExtraFeeTransferredEventName,
+			RewardCollectedEventName,
+			RelayerThresholdChangedEventName,
+			PausedEventName,
+			UnpausedEventName,
+			OwnershipTransferredEventName,
+		}
+	}
+	return []string{
+		DepositEventName,
+		ExtraFeeSuppliedEventName,
+		PausedEventName,
+		UnpausedEventName,
+		OwnershipTransferredEventName,
+	}
+}
+
+// bridgeABI returns ABI definition of bridge contract on the chain
+func bridgeABI(chainName string) string {
+	if chainName == "LA" {
+		return laBr.LaBrABI
+	}
+	return ethBr.EthBrABI
+}
+
+// eventIDs derives ids(topic0) of events from contract ABI definition,
+// it fails if ABI lacks any of them, so changed event signature is never missed silently
+func eventIDs(definition string, names []string) (map[common.Hash]string, error) {
+	contractABI, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[common.Hash]string, len(names))
+	for _, name := range names {
+		event, ok := contractABI.Events[name]
+		if !ok {
+			return nil, fmt.Errorf("event %s not found in contract ABI", name)
+		}
+		ids[event.ID] = name
+	}
+	return ids, nil
+}
+
+// topics returns filter of logs by ids of watched events
+func (w *Erc20Worker) topics() [][]common.Hash {
+	ids := make([]common.Hash, 0, len(w.events))
+	for id := range w.events {
+		ids = append(ids, id)
+	}
+	return [][]common.Hash{ids}
+}
+
+// isUnknownEvent returns true for bridge contract log with event id(topic0) missing in contract ABI
+func (w *Erc20Worker) isUnknownEvent(log *types.Log) bool {
+	if log.Address != w.contractAddr {
+		return false
+	}
+	if len(log.Topics) == 0 {
+		return true
+	}
+	_, watched := w.events[log.Topics[0]]
+	_, ignored := w.ignoredEvents[log.Topics[0]]
+	return !watched && !ignored
+}
+
+// ignoredEventIDs returns ids of events declared in contract ABI but not watched by the service,
+// their logs are skipped, while logs of events missing in ABI are quarantined
+func ignoredEventIDs(definition string, watched map[common.Hash]string) (map[common.Hash]string, error) {
+	contractABI, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[common.Hash]string)
+	for name, event := range contractABI.Events {
+		if _, ok := watched[event.ID]; !ok {
+			ids[event.ID] = name
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,76 @@
+package eth
+
+import (
+	"testing"
+
+	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
+	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	// keccak256 of 'Deposit(bytes8,bytes8,bytes32,uint64,address,address,address,uint256,bytes32)'
+	depositTopic = common.HexToHash("0x370525803ffa9a7c0e6adb3868e393dca45d8b42b2f62fd1f23ecfe99f6ce8fc")
+	// keccak256 of 'ProposalEvent(bytes8,bytes8,address,uint256,uint64,uint8,bytes32,bytes32)'
+	proposalEventTopic = common.HexToHash("0x9686dcabd0450cad86a88df15a9d35b08b35d1b08a19008df37cf8538c467516")
+)
+
+// pausedOnlyABI is contract ABI without 'Deposit' event
+const pausedOnlyABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"address","name":"account","type":"address"}],"name":"Paused","type":"event"}]`
+
+func TestEventIDs(t *testing.T) {
+	tests := []struct {
+		name        string
+		definition  string
+		names       []string
+		want        map[common.Hash]string
+		wantIgnored map[common.Hash]string
+		wantErr     bool
+	}{
+		{"LA bridge", laBr.LaBrABI, watchedEvents("LA"),
+			map[common.Hash]string{depositTopic: DepositEventName, proposalEventTopic: ProposalEventName},
+			map[common.Hash]string{}, false},
+		{"ETH bridge", ethBr.EthBrABI, watchedEvents("ETH"),
+			map[common.Hash]string{depositTopic: DepositEventName},
+			map[common.Hash]string{proposalEventTopic: ProposalEventName}, false},
+		{"ABI without Deposit", pausedOnlyABI, []string{PausedEventName, DepositEventName}, nil, nil, true},
+		{"broken ABI", "[{", []string{DepositEventName}, nil, nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := eventIDs(tt.definition, tt.names)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("want error, got %v", ids)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ids) != len(tt.names) {
+				t.Fatalf("got %d ids for %d events", len(ids), len(tt.names))
+			}
+			for id, name := range tt.want {
+				if ids[id] != name {
+					t.Fatalf("got %q for %s, want %s", ids[id], id.Hex(), name)
+				}
+			}
+
+			ignored, err := ignoredEventIDs(tt.definition, ids)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(ignored) != len(tt.wantIgnored) {
+				t.Fatalf("got ignored events %v, want %v", ignored, tt.wantIgnored)
+			}
+			for id, name := range tt.wantIgnored {
+				if ignored[id] != name {
+					t.Fatalf("got ignored %q for %s, want %s", ignored[id], id.Hex(), name)
+				}
+			}
+		})
+	}
+}
@@ -10,5 +10,5 @@ type Header struct {
 	Hash       common.Hash    `json:"hash"`
 	ParentHash common.Hash    `json:"parentHash"       gencodec:"required"`
 	Time       hexutil.Uint64 `json:"timestamp"        gencodec:"required"`
-	Number     hexutil.Uint64 `json:"number"					 gencodec:"required"`
+	Number     hexutil.Uint64 `json:"number"           gencodec:"required"`
 }
@@ -3,93 +3,127 @@ package eth
 import (
 	"bytes"
 	"context"
-	"crypto/ecdsa"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
-	"strconv"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/latoken/bridge-backend-service/src/service/metrics"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
 	ERC20 "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/ERC20"
 	aToken "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/atoken"
 	ethBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/eth"
 	laBr "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/bridge/la"
 	ethHandler "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/handler/eth"
 	laHandler "github.com/latoken/bridge-backend-service/src/service/workers/eth-compatible/abi/handler/la"
+	"github.com/latoken/bridge-backend-service/src/service/workers/signer"
 	"github.com/latoken/bridge-backend-service/src/service/workers/utils"
 
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/sirupsen/logrus"
-	jsonrpc "github.com/ybbus/jsonrpc/v2"
 
 	"github.com/latoken/bridge-backend-service/src/models"
 	"github.com/latoken/bridge-backend-service/src/service/storage"
 )
 
+const (
+	defaultBlockHistory = 100
+	// percent added to estimated gas
+	defaultGasMargin = 20
+)
+
+// errChainReorganised is returned when chain is reorganised between requests of one scan
+var errChainReorganised = errors.New("chain reorganised while scanning")
+
 // Erc20Worker ...
 type Erc20Worker struct {
-	provider           string
 	chainName          string
 	chainID            int64
 	destinationChainID string
 	storage            *storage.DataBase
 	logger             *logrus.Entry // logger
 	config             *models.WorkerConfig
-	client             *ethclient.Client
+	client             *multiClient
 	contractAddr       common.Address
+	nonces             *nonceManager
+	scan               *scanWindow
+	events             map[common.Hash]string
+	ignoredEvents      map[common.Hash]string
+	handlers           []common.Address
+	signer             signer.Signer
 }
 
 // NewErc20Worker ...
 func NewErc20Worker(logger *logrus.Logger, cfg *models.WorkerConfig, db *storage.DataBase) *Erc20Worker {
-	client, err := ethclient.Dial(cfg.Provider)
+	entry := logger.WithField("worker", cfg.ChainName)
+	client, err := newMultiClient(entry, cfg)
 	if err != nil {
 		panic(fmt.Sprintf("rpc error for chain %s: %s", cfg.ChainName, err.Error()))
 	}
+	checkInterval := cfg.ProviderCheckInterval
+	if checkInterval <= 0 {
+		checkInterval = defaultProviderCheckInterval
+	}
+	go client.run(time.Duration(checkInterval) * time.Second)
 
-	privKey, err := utils.GetPrivateKey(cfg)
+	events, err := eventIDs(bridgeABI(cfg.ChainName), watchedEvents(cfg.ChainName))
 	if err != nil {
-		panic(fmt.Sprintf("generate private key error, err=%s", err.Error()))
+		panic(fmt.Sprintf("bridge contract ABI of chain %s is not supported, err=%s", cfg.ChainName, err.Error()))
+	}
+	ignoredEvents, err := ignoredEventIDs(bridgeABI(cfg.ChainName), events)
+	if err != nil {
+		panic(fmt.Sprintf("bridge contract ABI of chain %s is not supported, err=%s", cfg.ChainName, err.Error()))
 	}
 
-	publicKey := privKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		panic(fmt.Sprintf("get public key error, err=%s", err.Error()))
+	txSigner, err := signer.NewSigner(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("create signer error, err=%s", err.Error()))
 	}
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+	chainid, err := client.ChainID(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("failed to get chain id for %s, with error: %s", cfg.ChainName, err))
+	}
 
-	if !bytes.Equal(cfg.WorkerAddr.Bytes(), fromAddress.Bytes()) {
+	if err := signer.CheckAddress(txSigner, cfg.WorkerAddr, chainid); err != nil {
 		panic(fmt.Sprintf(
-			"relayer address supplied in config (%s) does not match mnemonic (%s)",
-			cfg.WorkerAddr, fromAddress,
+			"relayer address supplied in config (%s) does not match signer, err=%s",
+			cfg.WorkerAddr, err,
 		))
 	}
 
-	chainid, err := client.ChainID(context.Background())
-	if err != nil {
-		panic(fmt.Sprintf("failed to get chain id for %s, with error: %s", cfg.ChainName, err))
+	nonces := newNonceManager(entry, cfg.ChainName, cfg.WorkerAddr, client, db)
+	if err := nonces.resync(); err != nil {
+		panic(fmt.Sprintf("failed to get nonce for %s, with error: %s", cfg.ChainName, err))
 	}
 
 	// init token addresses
-	return &Erc20Worker{
+	worker := &Erc20Worker{
 		chainName:          cfg.ChainName,
 		chainID:            chainid.Int64(),
 		destinationChainID: cfg.DestinationChainID,
-		logger:             logger.WithField("worker", cfg.ChainName),
-		provider:           cfg.Provider,
+		logger:             entry,
 		config:             cfg,
 		client:             client,
 		contractAddr:       cfg.ContractAddr,
 		storage:            db,
-	}
+		nonces:             nonces,
+		scan:               newScanWindow(entry, cfg.MaxBlockRange),
+		events:             events,
+		ignoredEvents:      ignoredEvents,
+		signer:             txSigner,
+	}
+	// handlers are watched for admin events with bridge contract
+	worker.handlers = worker.getHandlers()
+	return worker
 }
 
 // GetChainName returns chain ID
@@ -120,6 +154,19 @@ func (w *Erc20Worker) GetConfirmNum() int64 {
 	return w.config.ConfirmNum
 }
 
+// GetBlockHistory returns numbers of last block headers kept in database,
+// never less than numbers of confirmations
+func (w *Erc20Worker) GetBlockHistory() int64 {
+	history := w.config.BlockHistory
+	if history == 0 {
+		history = defaultBlockHistory
+	}
+	if history < w.config.ConfirmNum+1 {
+		history = w.config.ConfirmNum + 1
+	}
+	return history
+}
+
 func (w *Erc20Worker) GetConfig() *models.WorkerConfig {
 	return w.config
 }
@@ -133,59 +180,98 @@ func (w *Erc20Worker) getCallOpts() *bind.CallOpts {
 }
 
 func (w *Erc20Worker) ExecuteProposalEth(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string) (string, error) {
-	auth, err := w.getTransactor()
-	if err != nil {
-		return "", err
-	}
+	value, _ := new(big.Int).SetString(amount, 10)
+	return w.sendTx(ethBridgeABI, "executeProposal", originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), value, []byte(nil))
+}
 
-	instance, err := ethBr.NewEthBr(w.contractAddr, w.client)
+func (w *Erc20Worker) ExecuteProposalLa(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string, bytes []byte) (string, error) {
+	value, _ := new(big.Int).SetString(amount, 10)
+	return w.sendTx(laBridgeABI, "executeProposal", originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), value, bytes)
+}
+
+func (w *Erc20Worker) UpdateSwapStatusOnChain(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, outAmount, inAmount *big.Int, bytes []byte, status uint8) (string, error) {
+	return w.sendTx(laBridgeABI, "updateExternalTx", originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), outAmount, inAmount, bytes, status)
+}
+
+// sendTx packs call of the bridge contract method, simulates it and signs tx
+// with the next nonce of the worker only once, right before it is broadcasted.
+// Hash is returned with error when tx could reach the network despite the error
+func (w *Erc20Worker) sendTx(contractABI abi.ABI, method string, args ...interface{}) (string, error) {
+	defer func(start time.Time) {
+		metrics.SendDuration.WithLabelValues(w.chainName).Observe(time.Since(start).Seconds())
+	}(time.Now())
+
+	data, err := contractABI.Pack(method, args...)
 	if err != nil {
-		return "", err
+		return "", fmt.Errorf("pack %s call error: %w", method, err)
 	}
-	value, _ := new(big.Int).SetString(amount, 10)
-	tx, err := instance.ExecuteProposal(auth, originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), value, nil)
+
+	// nonce is not taken for calls reverted in simulation
+	gasLimit, err := w.simulateTx(data)
 	if err != nil {
 		return "", err
 	}
 
-	return tx.Hash().String(), nil
-}
-
-func (w *Erc20Worker) ExecuteProposalLa(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string, bytes []byte) (string, error) {
 	auth, err := w.getTransactor()
 	if err != nil {
 		return "", err
 	}
+	nonce := auth.Nonce.Uint64()
+	auth.GasLimit = gasLimit
 
-	instance, err := laBr.NewLaBr(w.contractAddr, w.client)
+	// tx is signed without sending, so errors of signing and of broadcast are told apart
+	auth.NoSend = true
+	contract := bind.NewBoundContract(w.contractAddr, contractABI, w.client, w.client, w.client)
+	tx, err := contract.RawTransact(auth, data)
 	if err != nil {
+		w.nonces.release(nonce, err)
 		return "", err
 	}
-	value, _ := new(big.Int).SetString(amount, 10)
-	tx, err := instance.ExecuteProposal(auth, originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), value, bytes)
-	if err != nil {
-		return "", err
+
+	if err := w.client.SendTransaction(context.Background(), tx); err != nil {
+		if isRejectedTx(err) {
+			w.nonces.release(nonce, err)
+			return "", err
+		}
+		// tx could reach the network before the error, its nonce is never handed out again,
+		// the tx is replaced or found lost by its hash later
+		w.logger.Warnf("broadcast of tx %s with nonce %d failed, nonce is kept, err = %v", tx.Hash().Hex(), nonce, err)
+		w.nonces.commit(nonce)
+		w.saveSignedTx(tx)
+		return tx.Hash().String(), err
 	}
+	w.nonces.commit(nonce)
+	w.saveSignedTx(tx)
 
 	return tx.Hash().String(), nil
 }
 
-func (w *Erc20Worker) UpdateSwapStatusOnChain(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, outAmount, inAmount *big.Int, bytes []byte, status uint8) (string, error) {
-	auth, err := w.getTransactor()
-	if err != nil {
-		return "", err
+// simulateTx dry-runs calldata from the worker address with eth_call and returns estimated
+// gas limit with safety margin, gas limit from config is used if estimation fails. Nothing is signed
+func (w *Erc20Worker) simulateTx(data []byte) (uint64, error) {
+	msg := ethereum.CallMsg{
+		From: w.signer.Address(),
+		To:   &w.contractAddr,
+		Data: data,
+	}
+	if _, err := w.client.CallContract(context.Background(), msg, nil); err != nil {
+		if reason, ok := revertReason(err); ok {
+			return 0, &workers.RevertError{Reason: reason}
+		}
+		return 0, err
 	}
 
-	instance, err := laBr.NewLaBr(w.contractAddr, w.client)
+	gas, err := w.client.EstimateGas(context.Background(), msg)
 	if err != nil {
-		return "", err
+		w.logger.Warnf("estimate gas error, fallback to gas limit from config, err = %v", err)
+		return uint64(w.config.GasLimit), nil
 	}
 
-	tx, err := instance.UpdateExternalTx(auth, originChainID, destinationChainID, depositNonce, resourceID, common.HexToAddress(receiptAddr), outAmount, inAmount, bytes, status)
-	if err != nil {
-		return "", err
+	margin := w.config.GasMargin
+	if margin <= 0 {
+		margin = defaultGasMargin
 	}
-	return tx.Hash().String(), nil
+	return gas * uint64(100+margin) / 100, nil
 }
 
 func (w *Erc20Worker) GetLiquidityIndex(handlerAddress, amUsdtAddress common.Address) ([]byte, error) {
@@ -221,98 +307,245 @@ func (w *Erc20Worker) GetStatus() (*models.WorkerStatus, error) {
 	status.Height = height
 	// set worker address
 	status.Account.Address = w.config.WorkerAddr.Hex()
+	// set nonce state
+	status.Nonce, err = w.nonces.status()
+	if err != nil {
+		return nil, err
+	}
+	// set health of providers
+	status.Providers = w.client.status()
+	// set progress of logs scanning
+	status.Scan = w.scan.getStatus()
 
 	return status, nil
 }
 
 // GetBlockAndTxs ...
-func (w *Erc20Worker) GetBlockAndTxs(height int64) (*models.BlockAndTxLogs, error) {
-	clientResp, err := w.client.HeaderByNumber(context.Background(), nil)
+// Head, header and logs are taken from one provider, so logs of blocks not seen yet by a lagging
+// provider are never returned as empty
+func (w *Erc20Worker) GetBlockAndTxs(height int64) (blockAndTxLogs *models.BlockAndTxLogs, err error) {
+	err = w.client.pinned("scan", func(client scanClient) (err error) {
+		blockAndTxLogs, err = w.scanBlocks(client, height)
+		return
+	})
+	return
+}
+
+func (w *Erc20Worker) scanBlocks(client scanClient, height int64) (*models.BlockAndTxLogs, error) {
+	clientResp, err := client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
 		w.logger.Errorln("Error while fetching the block header = ", err)
 		return nil, err
 
 	}
 
-	nextHeight := clientResp.Number.Int64()
-	if height >= nextHeight {
-		return nil, fmt.Errorf("not found")
+	head := clientResp.Number.Int64()
+	if height >= head {
+		return nil, ethereum.NotFound
 	} else if height == 0 {
-		height = nextHeight - 1
-	} else if nextHeight-height >= 100 {
-		nextHeight = height + 20
+		height = head - 1
 	}
 
-	logs, err := w.getLogs(height, nextHeight)
+	nextHeight := w.scan.next(height, head)
+	header, logs, quarantined, err := w.getBlockLogs(client, height, nextHeight)
+	for err != nil && isRangeError(err) && w.scan.shrink(err) {
+		nextHeight = w.scan.next(height, head)
+		header, logs, quarantined, err = w.getBlockLogs(client, height, nextHeight)
+	}
 	if err != nil {
 		w.logger.Errorf("while getEvents(block number from %d to %d), err = %v", height, nextHeight, err)
 		return nil, err
 	}
+	w.scan.grow()
+	w.scan.progress(nextHeight, head)
 
 	return &models.BlockAndTxLogs{
 		Height:          nextHeight,
-		BlockHash:       clientResp.Hash().String(),
-		ParentBlockHash: clientResp.ParentHash.Hex(),
-		BlockTime:       int64(clientResp.Time),
+		BlockHash:       header.Hash.Hex(),
+		ParentBlockHash: header.ParentHash.Hex(),
+		BlockTime:       int64(header.Time),
 		TxLogs:          logs,
+		QuarantinedLogs: quarantined,
 	}, nil
 }
 
+// getBlockLogs returns header of the block at nextHeight, which is stored as cursor, with logs of blocks range.
+// Header is fetched before logs and logs of nextHeight must be in this block, otherwise
+// chain has been reorganised in between and logs could come from orphaned branch
+func (w *Erc20Worker) getBlockLogs(client scanClient, curHeight, nextHeight int64) (*Header, []*storage.TxLog, []*storage.QuarantinedLog, error) {
+	header, err := headerByNumber(client, nextHeight)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("fetch block header at %d: %w", nextHeight, err)
+	}
+
+	logs, quarantined, err := w.getLogs(client, curHeight, nextHeight)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	blockHash := header.Hash.Hex()
+	for _, log := range logs {
+		if log.Height == nextHeight && log.BlockHash != blockHash {
+			return nil, nil, nil, fmt.Errorf("log %s of block %d is in %s, header is %s: %w",
+				log.TxHash, nextHeight, log.BlockHash, blockHash, errChainReorganised)
+		}
+	}
+	for _, log := range quarantined {
+		if log.Height == nextHeight && log.BlockHash != blockHash {
+			return nil, nil, nil, fmt.Errorf("log %s of block %d is in %s, header is %s: %w",
+				log.TxHash, nextHeight, log.BlockHash, blockHash, errChainReorganised)
+		}
+	}
+	if err := setLogsTime(client, header, logs); err != nil {
+		return nil, nil, nil, err
+	}
+	return header, logs, quarantined, nil
+}
+
+// setLogsTime sets timestamp of the block to logs, headers of blocks below nextHeight are fetched once per block
+func setLogsTime(client scanClient, header *Header, logs []*storage.TxLog) error {
+	times := map[int64]int64{int64(header.Number): int64(header.Time)}
+	for _, log := range logs {
+		blockTime, ok := times[log.Height]
+		if !ok {
+			logHeader, err := headerByNumber(client, log.Height)
+			if err != nil {
+				return fmt.Errorf("fetch block header at %d: %w", log.Height, err)
+			}
+			blockTime = int64(logHeader.Time)
+			times[log.Height] = blockTime
+		}
+		log.Timestamp = blockTime
+	}
+	return nil
+}
+
+// GetScanStatus returns progress of logs scanning
+func (w *Erc20Worker) GetScanStatus() models.ScanStatus {
+	return w.scan.getStatus()
+}
+
+// GetBlockHash returns hash of the block at height as reported by the node
+func (w *Erc20Worker) GetBlockHash(height int64) (string, error) {
+	header, err := headerByNumber(w.client, height)
+	if err != nil {
+		return "", err
+	}
+	return header.Hash.Hex(), nil
+}
+
+// headerByNumber takes block hash from rpc instead of computing it from header fields,
+// which gives wrong hashes on chains with non-ethereum headers
+func headerByNumber(client scanClient, height int64) (*Header, error) {
+	var header *Header
+	err := client.CallContext(context.Background(), &header, "eth_getBlockByNumber", hexutil.EncodeBig(big.NewInt(height)), false)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	return header, nil
+}
+
 // GetFetchInterval ...
 func (w *Erc20Worker) GetFetchInterval() time.Duration {
 	return time.Duration(w.config.FetchInterval) * time.Second
 }
 
 // getLogs ...
-func (w *Erc20Worker) getLogs(curHeight, nextHeight int64) ([]*storage.TxLog, error) {
-	//	topics := [][]common.Hash{{DepositEventHash, ProposalEventHash, ProposalVoteHash}}
-
-	logs, err := w.client.FilterLogs(context.Background(), ethereum.FilterQuery{
-		// BlockHash: &blockHash,
-		FromBlock: big.NewInt(curHeight + 1),
-		ToBlock:   big.NewInt(nextHeight),
-		// Topics:    topics,
-		Addresses: []common.Address{w.contractAddr},
-		Topics:    [][]common.Hash{},
-	})
-
+func (w *Erc20Worker) getLogs(client scanClient, curHeight, nextHeight int64) ([]*storage.TxLog, []*storage.QuarantinedLog, error) {
+	fromBlock, toBlock := big.NewInt(curHeight+1), big.NewInt(nextHeight)
+	// all logs of bridge contract are taken, logs of events unknown to the service are quarantined by parseEvent
+	// instead of being skipped silently
+	logs, err := client.FilterLogs(context.Background(), w.bridgeLogsQuery(fromBlock, toBlock))
 	if err != nil {
-		w.logger.Info("ERR")
 		w.logger.WithFields(logrus.Fields{"function": "GetLogs()"}).Errorf("get event log error, err=%s", err)
-		return nil, err
+		return nil, nil, err
 	}
+	if len(w.handlers) > 0 {
+		handlerLogs, err := client.FilterLogs(context.Background(), w.handlerLogsQuery(fromBlock, toBlock))
+		if err != nil {
+			w.logger.WithFields(logrus.Fields{"function": "GetLogs()"}).Errorf("get handler event log error, err=%s", err)
+			return nil, nil, err
+		}
+		logs = append(logs, handlerLogs...)
+		sort.SliceStable(logs, func(i, j int) bool {
+			if logs[i].BlockNumber != logs[j].BlockNumber {
+				return logs[i].BlockNumber < logs[j].BlockNumber
+			}
+			return logs[i].Index < logs[j].Index
+		})
+	}
+
+	txLogs, quarantined := w.toTxLogs(logs)
+	return txLogs, quarantined, nil
+}
 
+// bridgeLogsQuery returns query of all bridge contract logs in blocks range, nil bounds are open
+func (w *Erc20Worker) bridgeLogsQuery(fromBlock, toBlock *big.Int) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: []common.Address{w.contractAddr},
+	}
+}
+
+// handlerLogsQuery returns query of watched events logs of handler contracts in blocks range, nil bounds are open
+func (w *Erc20Worker) handlerLogsQuery(fromBlock, toBlock *big.Int) ethereum.FilterQuery {
+	return ethereum.FilterQuery{
+		FromBlock: fromBlock,
+		ToBlock:   toBlock,
+		Addresses: w.handlers,
+		Topics:    w.topics(),
+	}
+}
+
+// toTxLogs parses bridge contract logs into txs, logs failed parsing are returned to be quarantined
+func (w *Erc20Worker) toTxLogs(logs []types.Log) ([]*storage.TxLog, []*storage.QuarantinedLog) {
 	models := make([]*storage.TxLog, 0, len(logs))
+	quarantined := make([]*storage.QuarantinedLog, 0)
 	for _, log := range logs {
 		w.logger.Infof("WORKER(%s) NEW EVENT: %v\n\n", w.chainName, log)
-		event, err := w.parseEvent(&log)
+		txLog, err := w.toTxLog(&log)
 		if err != nil {
-			w.logger.WithFields(logrus.Fields{"function": "GetLogs()"}).Errorf("parse event log error, err=%s", err)
+			w.logger.WithFields(logrus.Fields{"function": "GetLogs()"}).Errorf("parse event log error, quarantine log %s:%d, err=%s",
+				log.TxHash.Hex(), log.Index, err)
+			quarantined = append(quarantined, w.quarantine(&log, err))
 			continue
 		}
-		if event == nil {
+		if txLog == nil {
 			continue
 		}
 
-		txLog := event.ToTxLog(w.chainName)
-		txLog.Chain = w.chainName
-		txLog.Height = int64(log.BlockNumber)
-		txLog.EventID = log.TxHash.Hex()
-		txLog.BlockHash = log.BlockHash.Hex()
-		txLog.TxHash = log.TxHash.Hex()
-		txLog.Status = storage.TxStatusInit
-
 		models = append(models, txLog)
 	}
 
-	return models, nil
+	return models, quarantined
+}
+
+// toTxLog parses log into tx, nil is returned for logs not watched by the service
+func (w *Erc20Worker) toTxLog(log *types.Log) (*storage.TxLog, error) {
+	event, err := w.parseEvent(log)
+	if err != nil || event == nil {
+		return nil, err
+	}
+
+	txLog := event.ToTxLog(w.chainName)
+	txLog.Chain = w.chainName
+	txLog.Height = int64(log.BlockNumber)
+	txLog.EventID = log.TxHash.Hex()
+	txLog.BlockHash = log.BlockHash.Hex()
+	txLog.TxHash = log.TxHash.Hex()
+	txLog.Status = storage.TxStatusInit
+	return txLog, nil
 }
 
 // GetHeight ..
 func (w *Erc20Worker) GetHeight() (int64, error) {
 	header, err := w.client.HeaderByNumber(context.Background(), nil)
 	if err != nil {
-		return 0, nil
+		return 0, err
 	}
 	return header.Number.Int64(), nil
 }
@@ -345,46 +578,30 @@ func (w *Erc20Worker) GetSentTxStatus(hash string) storage.TxStatus {
 
 func (w *Erc20Worker) GetTxCountLatest() (uint64, error) {
 	var result hexutil.Uint64
-	rpcClient := jsonrpc.NewClient(w.provider)
-
-	resp, err := rpcClient.Call("eth_getTransactionCount", w.config.WorkerAddr.Hex(), "latest")
+	err := w.client.CallContext(context.Background(), &result, "eth_getTransactionCount", w.config.WorkerAddr.Hex(), "latest")
 	if err != nil {
 		return 0, err
 	}
-	if err := resp.GetObject(&result); err != nil {
-		return 0, err
-	}
 	return uint64(result), nil
 }
 
 // GetTransactor ...
 func (w *Erc20Worker) getTransactor() (auth *bind.TransactOpts, err error) {
-	privateKey, err := utils.GetPrivateKey(w.config)
-	if err != nil {
-		return nil, err
-	}
-
-	var nonce uint64
-	nonce, err = w.client.PendingNonceAt(context.Background(), w.config.WorkerAddr)
-	if err != nil {
-		return nil, err
+	auth = &bind.TransactOpts{
+		From: w.signer.Address(),
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != w.signer.Address() {
+				return nil, bind.ErrNotAuthorized
+			}
+			return w.signer.SignTx(tx, big.NewInt(w.chainID))
+		},
+		Context: context.Background(),
 	}
-	// }
 
-	auth, err = bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(w.chainID))
-	if err != nil {
+	if err := w.setFees(auth); err != nil {
 		return nil, err
 	}
-
-	var gasPrice float64
-	gasPriceGWei, _ := strconv.ParseFloat(w.storage.GetGasPrice(w.chainName).Price, 64)
-	if gasPriceGWei > 0 {
-		gasPrice = gasPriceGWei * 1000000000
-	} else {
-		gasPrice = w.GetGasPrice()
-	}
-	auth.GasPrice = big.NewInt((int64(gasPrice)))
-	auth.Nonce = big.NewInt(int64(nonce))
+	auth.Nonce = new(big.Int).SetUint64(w.nonces.acquire())
 	auth.Value = big.NewInt(0)                // in wei
 	auth.GasLimit = uint64(w.config.GasLimit) // in units
 
@@ -396,6 +613,11 @@ func (w *Erc20Worker) EthBalance(address common.Address) (*big.Int, error) {
 	return w.client.BalanceAt(context.Background(), address, nil)
 }
 
+// GetWorkerBalance ...
+func (w *Erc20Worker) GetWorkerBalance() (*big.Int, error) {
+	return w.EthBalance(w.config.WorkerAddr)
+}
+
 // GetWorkerAddress ...
 func (w *Erc20Worker) GetWorkerAddress() string {
 	return w.config.WorkerAddr.String()
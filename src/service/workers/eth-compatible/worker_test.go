@@ -0,0 +1,126 @@
+package eth
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+	workers "github.com/latoken/bridge-backend-service/src/service/workers"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// countingSigner counts signing requests and refuses them
+type countingSigner struct {
+	address common.Address
+	signs   int
+}
+
+func (s *countingSigner) Address() common.Address { return s.address }
+
+func (s *countingSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	s.signs++
+	return nil, errors.New("not expected to sign")
+}
+
+type simulationArgs struct {
+	From  common.Address  `json:"from"`
+	To    *common.Address `json:"to"`
+	Data  hexutil.Bytes   `json:"data"`
+	Value *hexutil.Big    `json:"value"`
+}
+
+// fakeSimulationChain answers 'eth_call' and 'eth_estimateGas' and keeps their arguments
+type fakeSimulationChain struct {
+	revert    []byte
+	gas       uint64
+	calls     []simulationArgs
+	estimates []simulationArgs
+}
+
+func (c *fakeSimulationChain) Call(args simulationArgs, block string) (hexutil.Bytes, error) {
+	c.calls = append(c.calls, args)
+	if c.revert != nil {
+		return nil, &revertError{data: hexutil.Encode(c.revert)}
+	}
+	return hexutil.Bytes{}, nil
+}
+
+func (c *fakeSimulationChain) EstimateGas(args simulationArgs) (hexutil.Uint64, error) {
+	c.estimates = append(c.estimates, args)
+	if c.gas == 0 {
+		return 0, errors.New("gas required exceeds allowance")
+	}
+	return hexutil.Uint64(c.gas), nil
+}
+
+func TestSimulateTx(t *testing.T) {
+	data, err := ethBridgeABI.Pack("executeProposal", [8]byte{1}, [8]byte{2}, uint64(7), [32]byte{3},
+		common.HexToAddress("0x4"), big.NewInt(1000), []byte(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		chain   *fakeSimulationChain
+		margin  int64
+		want    uint64
+		wantErr string
+	}{
+		{"default margin", &fakeSimulationChain{gas: 100000}, 0, 120000, ""},
+		{"configured margin", &fakeSimulationChain{gas: 100000}, 50, 150000, ""},
+		{"estimation failed", &fakeSimulationChain{}, 0, 300000, ""},
+		{"reverted", &fakeSimulationChain{revert: revertData(t, "Error(string)", []string{"string"}, "proposal already executed"), gas: 100000},
+			0, 0, "proposal already executed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			txSigner := &countingSigner{address: common.HexToAddress("0x5")}
+			w := &Erc20Worker{
+				chainName:    "ETH",
+				config:       &models.WorkerConfig{GasLimit: 300000, GasMargin: tt.margin},
+				client:       newTestClient(t, tt.chain),
+				contractAddr: common.HexToAddress("0x6"),
+				signer:       txSigner,
+				logger:       testLogger(),
+			}
+
+			got, err := w.simulateTx(data)
+			if txSigner.signs != 0 {
+				t.Fatalf("tx is signed %d times in simulation", txSigner.signs)
+			}
+			if tt.wantErr != "" {
+				var revertErr *workers.RevertError
+				if !errors.As(err, &revertErr) || revertErr.Reason != tt.wantErr {
+					t.Fatalf("got error %v, want revert %q", err, tt.wantErr)
+				}
+				if len(tt.chain.estimates) != 0 {
+					t.Fatal("gas is estimated for reverted call")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Fatalf("got gas limit %d, want %d", got, tt.want)
+			}
+
+			for _, args := range append(tt.chain.calls, tt.chain.estimates...) {
+				if args.From != txSigner.address || args.To == nil || *args.To != w.contractAddr || !bytes.Equal(args.Data, data) {
+					t.Fatalf("simulated from %s to %v with data %x, want from %s to %s with packed call",
+						args.From.Hex(), args.To, []byte(args.Data), txSigner.address.Hex(), w.contractAddr.Hex())
+				}
+			}
+			if len(tt.chain.calls) != 1 || len(tt.chain.estimates) != 1 {
+				t.Fatalf("got %d calls and %d estimates, want 1 of each", len(tt.chain.calls), len(tt.chain.estimates))
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package signer
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+)
+
+// NewKeystoreSigner creates signer from encrypted go-ethereum keystore file,
+// passphrase is taken from env variable or from file
+func NewKeystoreSigner(path, passphraseEnv, passphraseFile string) (Signer, error) {
+	keyJSON, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read keystore file error, err=%w", err)
+	}
+
+	passphrase, err := readPassphrase(passphraseEnv, passphraseFile)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := keystore.DecryptKey(keyJSON, passphrase)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt keystore file error, err=%w", err)
+	}
+
+	return newKeySigner(key.PrivateKey), nil
+}
+
+func readPassphrase(passphraseEnv, passphraseFile string) (string, error) {
+	if passphraseEnv != "" {
+		passphrase, ok := os.LookupEnv(passphraseEnv)
+		if !ok {
+			return "", fmt.Errorf("env variable %s with keystore passphrase is not set", passphraseEnv)
+		}
+		return passphrase, nil
+	}
+	if passphraseFile != "" {
+		passphrase, err := ioutil.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase file error, err=%w", err)
+		}
+		return strings.TrimRight(string(passphrase), "\r\n"), nil
+	}
+	return "", fmt.Errorf("keystore passphrase is not configured")
+}
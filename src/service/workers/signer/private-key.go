@@ -0,0 +1,43 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// privateKeySigner signs txs with private key kept in memory
+type privateKeySigner struct {
+	privateKey *ecdsa.PrivateKey
+	address    common.Address
+}
+
+// NewPrivateKeySigner creates signer from raw hex private key, for development only
+func NewPrivateKeySigner(hexKey string) (Signer, error) {
+	privateKey, err := crypto.HexToECDSA(hexKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return newKeySigner(privateKey), nil
+}
+
+func newKeySigner(privateKey *ecdsa.PrivateKey) *privateKeySigner {
+	return &privateKeySigner{
+		privateKey: privateKey,
+		address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+	}
+}
+
+// Address ...
+func (s *privateKeySigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx ...
+func (s *privateKeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return types.SignTx(tx, types.LatestSignerForChainID(chainID), s.privateKey)
+}
@@ -0,0 +1,175 @@
+package signer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	jsonrpc "github.com/ybbus/jsonrpc/v2"
+)
+
+// defaultRemoteSignerTimeout is timeout of request to remote signer in seconds if it is not configured
+const defaultRemoteSignerTimeout = 10
+
+// remoteSigner signs txs with eth_signTransaction of remote signer(web3signer, clef and etc),
+// private key never leaves the signer
+type remoteSigner struct {
+	client  jsonrpc.RPCClient
+	address common.Address
+}
+
+// txArgs are params of eth_signTransaction
+type txArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big    `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	ChainID              *hexutil.Big    `json:"chainId"`
+}
+
+// signTxResult is result of eth_signTransaction in clef format, web3signer returns raw tx only
+type signTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+// NewRemoteSigner creates signer of the address which key is held by remote signer,
+// bearer token of the signer is taken from env variable
+func NewRemoteSigner(cfg models.SignerConfig, address common.Address) (Signer, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("remote signer url is not configured")
+	}
+
+	var token string
+	if cfg.TokenEnv != "" {
+		var ok bool
+		if token, ok = os.LookupEnv(cfg.TokenEnv); !ok {
+			return nil, fmt.Errorf("env variable %s with remote signer token is not set", cfg.TokenEnv)
+		}
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultRemoteSignerTimeout
+	}
+	return newRemoteSigner(cfg.URL, address, token, time.Duration(timeout)*time.Second), nil
+}
+
+func newRemoteSigner(url string, address common.Address, token string, timeout time.Duration) *remoteSigner {
+	opts := &jsonrpc.RPCClientOpts{
+		HTTPClient: &http.Client{Timeout: timeout},
+	}
+	if token != "" {
+		opts.CustomHeaders = map[string]string{"Authorization": "Bearer " + token}
+	}
+	return &remoteSigner{
+		client:  jsonrpc.NewClientWithOpts(url, opts),
+		address: address,
+	}
+}
+
+// Address ...
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs tx by remote signer and checks that the signer has signed exactly this tx
+// by the worker account, so compromised or misconfigured signer can't send another tx
+func (s *remoteSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	args := txArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		ChainID: (*hexutil.Big)(chainID),
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	} else {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	}
+
+	resp, err := s.client.Call("eth_signTransaction", []interface{}{args})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+
+	var result json.RawMessage
+	if err := resp.GetObject(&result); err != nil {
+		return nil, err
+	}
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err != nil {
+		var clefResult signTxResult
+		if err := json.Unmarshal(result, &clefResult); err != nil {
+			return nil, fmt.Errorf("unexpected eth_signTransaction result %s", result)
+		}
+		raw = clefResult.Raw
+	}
+
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, err
+	}
+	if err := s.verify(tx, signedTx, chainID); err != nil {
+		return nil, fmt.Errorf("remote signer returned invalid tx: %w", err)
+	}
+	return signedTx, nil
+}
+
+// verify checks that signedTx is tx signed by the signer address for the chain
+func (s *remoteSigner) verify(tx, signedTx *types.Transaction, chainID *big.Int) error {
+	switch {
+	case signedTx.Type() != tx.Type():
+		return fmt.Errorf("type %d, want %d", signedTx.Type(), tx.Type())
+	case signedTx.Nonce() != tx.Nonce():
+		return fmt.Errorf("nonce %d, want %d", signedTx.Nonce(), tx.Nonce())
+	case !sameAddress(signedTx.To(), tx.To()):
+		return fmt.Errorf("to %v, want %v", signedTx.To(), tx.To())
+	case signedTx.Value().Cmp(tx.Value()) != 0:
+		return fmt.Errorf("value %s, want %s", signedTx.Value(), tx.Value())
+	case !bytes.Equal(signedTx.Data(), tx.Data()):
+		return fmt.Errorf("data 0x%x, want 0x%x", signedTx.Data(), tx.Data())
+	case signedTx.Gas() != tx.Gas():
+		return fmt.Errorf("gas %d, want %d", signedTx.Gas(), tx.Gas())
+	case signedTx.GasFeeCap().Cmp(tx.GasFeeCap()) != 0 || signedTx.GasTipCap().Cmp(tx.GasTipCap()) != 0:
+		return fmt.Errorf("gas price %s, want %s", signedTx.GasFeeCap(), tx.GasFeeCap())
+	case !signedTx.Protected() || signedTx.ChainId().Cmp(chainID) != 0:
+		return fmt.Errorf("chain id %s, want %s", signedTx.ChainId(), chainID)
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return err
+	}
+	if from != s.address {
+		return fmt.Errorf("signed by %s instead of %s", from, s.address)
+	}
+	return nil
+}
+
+func sameAddress(a, b *common.Address) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
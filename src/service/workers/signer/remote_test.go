@@ -0,0 +1,130 @@
+package signer
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// stubSigner is eth_signTransaction endpoint of remote signer, tamper changes tx before it is signed
+type stubSigner struct {
+	key     *ecdsa.PrivateKey
+	token   string
+	clef    bool
+	delay   time.Duration
+	tamper  func(tx *types.LegacyTx)
+	chainID *big.Int
+}
+
+func (s *stubSigner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	time.Sleep(s.delay)
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	var req struct {
+		ID     int      `json:"id"`
+		Method string   `json:"method"`
+		Params []txArgs `json:"params"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Method != "eth_signTransaction" || len(req.Params) != 1 {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	args := req.Params[0]
+	txData := &types.LegacyTx{
+		Nonce:    uint64(args.Nonce),
+		To:       args.To,
+		Gas:      uint64(args.Gas),
+		GasPrice: args.GasPrice.ToInt(),
+		Value:    args.Value.ToInt(),
+		Data:     args.Data,
+	}
+	if s.tamper != nil {
+		s.tamper(txData)
+	}
+	chainID := args.ChainID.ToInt()
+	if s.chainID != nil {
+		chainID = s.chainID
+	}
+	signedTx, err := types.SignTx(types.NewTx(txData), types.LatestSignerForChainID(chainID), s.key)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	raw, _ := signedTx.MarshalBinary()
+
+	var result interface{} = hexutil.Bytes(raw)
+	if s.clef {
+		result = signTxResult{Raw: raw}
+	}
+	json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result})
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	to := common.HexToAddress("0xb1")
+	chainID := big.NewInt(5)
+
+	tests := []struct {
+		name    string
+		stub    *stubSigner
+		token   string
+		wantErr string
+	}{
+		{"signed", &stubSigner{key: key, token: "secret"}, "secret", ""},
+		{"signed in clef format", &stubSigner{key: key, token: "secret", clef: true}, "secret", ""},
+		{"wrong token", &stubSigner{key: key, token: "secret"}, "other", "401"},
+		{"timeout", &stubSigner{key: key, token: "secret", delay: 300 * time.Millisecond}, "secret", "Timeout"},
+		{"signed by other key", &stubSigner{key: otherKey, token: "secret"}, "secret", "invalid tx: signed by"},
+		{"other chain", &stubSigner{key: key, token: "secret", chainID: big.NewInt(1)}, "secret", "invalid tx: chain id "},
+		{"other nonce", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.Nonce++ }}, "secret", "invalid tx: nonce "},
+		{"other receiver", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.To = &address }}, "secret", "invalid tx: to "},
+		{"other value", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.Value = big.NewInt(1) }}, "secret", "invalid tx: value "},
+		{"other data", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.Data = []byte{1} }}, "secret", "invalid tx: data "},
+		{"other gas", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.Gas++ }}, "secret", "invalid tx: gas "},
+		{"other gas price", &stubSigner{key: key, token: "secret", tamper: func(tx *types.LegacyTx) { tx.GasPrice = big.NewInt(1) }}, "secret", "invalid tx: gas price "},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.stub)
+			defer server.Close()
+
+			s := newRemoteSigner(server.URL, address, tt.token, 100*time.Millisecond)
+			tx := types.NewTransaction(7, to, big.NewInt(0), 250000, big.NewInt(1000000000), []byte{0xa9, 0x05, 0x9c, 0xbb})
+			signedTx, err := s.SignTx(tx, chainID)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if signedTx.Hash() == tx.Hash() || signedTx.Nonce() != tx.Nonce() {
+				t.Fatalf("tx is not signed")
+			}
+		})
+	}
+}
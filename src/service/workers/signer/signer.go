@@ -0,0 +1,62 @@
+package signer
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/latoken/bridge-backend-service/src/models"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	TypePrivateKey = "private_key"
+	TypeKeystore   = "keystore"
+	TypeRemote     = "remote"
+)
+
+// Signer signs txs of the worker account
+type Signer interface {
+	// Address returns address of the account txs are signed with
+	Address() common.Address
+	// SignTx returns tx signed for the chain
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+}
+
+// NewSigner creates signer of the worker by signer type from config, raw private key is used by default
+func NewSigner(cfg *models.WorkerConfig) (Signer, error) {
+	switch cfg.Signer.Type {
+	case "", TypePrivateKey:
+		return NewPrivateKeySigner(cfg.PrivateKey)
+	case TypeKeystore:
+		return NewKeystoreSigner(cfg.Signer.KeystorePath, cfg.Signer.PassphraseEnv, cfg.Signer.PassphraseFile)
+	case TypeRemote:
+		return NewRemoteSigner(cfg.Signer, cfg.WorkerAddr)
+	default:
+		return nil, fmt.Errorf("unknown signer type %s for chain %s", cfg.Signer.Type, cfg.ChainName)
+	}
+}
+
+// CheckAddress signs probe tx, which is never sent, and checks that it is signed by the address
+func CheckAddress(signer Signer, address common.Address, chainID *big.Int) error {
+	probe := types.NewTx(&types.LegacyTx{
+		To:       &address,
+		Gas:      21000,
+		GasPrice: big.NewInt(0),
+		Value:    big.NewInt(0),
+	})
+	signedTx, err := signer.SignTx(probe, chainID)
+	if err != nil {
+		return err
+	}
+
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signedTx)
+	if err != nil {
+		return err
+	}
+	if from != address {
+		return fmt.Errorf("tx is signed by %s instead of %s", from, address)
+	}
+	return nil
+}
@@ -1,26 +1,15 @@
 package utils
 
 import (
-	"crypto/ecdsa"
+	"encoding/binary"
 	"math"
 	"math/big"
 	"strconv"
 
-	"github.com/latoken/bridge-backend-service/src/models"
-
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
-func GetPrivateKey(config *models.WorkerConfig) (*ecdsa.PrivateKey, error) {
-	privKey, err := crypto.HexToECDSA(config.PrivateKey)
-	if err != nil {
-		return nil, err
-	}
-
-	return privKey, nil
-}
-
 // QuoBigInt ...
 func QuoBigInt(a *big.Int, b *big.Int) *big.Float {
 	fl := new(big.Float).SetInt(a)
@@ -77,6 +66,16 @@ func CalcutateSwapID(originChainID, destChainID, nonce string) string {
 	return originChainID + destChainID + nonce
 }
 
+// ProposalKeys returns keys of '_executedProposals' mapping of eth bridge contract:
+// keccak256(depositNonce, originChainID, destinationChainID) and keccak256(resourceID, recipient, amount)
+func ProposalKeys(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, recipient common.Address, amount *big.Int) ([32]byte, [32]byte) {
+	nonce := make([]byte, 8)
+	binary.BigEndian.PutUint64(nonce, depositNonce)
+	nonceAndID := crypto.Keccak256Hash(nonce, originChainID[:], destinationChainID[:])
+	dataHash := crypto.Keccak256Hash(resourceID[:], recipient.Bytes(), common.LeftPadBytes(amount.Bytes(), 32))
+	return nonceAndID, dataHash
+}
+
 func ConvertDecimals(amount string, inDecimals, outDecimals int64) int64 {
 	value, _ := strconv.ParseInt(amount, 10, 32)
 	ret := value * outDecimals / inDecimals
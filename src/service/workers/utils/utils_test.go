@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// packed builds abi.encodePacked of hex fields, it is written apart from ProposalKeys on purpose
+func packed(fields ...string) common.Hash {
+	return crypto.Keccak256Hash(common.FromHex(strings.Join(fields, "")))
+}
+
+func TestProposalKeys(t *testing.T) {
+	recipient := "8f4a5fb5b2b9e46de5e3ad2a1f2cd62d2a6d5c21"
+	resourceID := "0000000000000000000000e9e7cea3dedca5984780bafc599bd69add087d5601"
+	tests := []struct {
+		name         string
+		nonce        uint64
+		origin       string
+		destination  string
+		amount       string
+		wantNonceKey common.Hash
+		wantDataKey  common.Hash
+	}{
+		{
+			"first deposit", 1, "0000000000000001", "0000000000000004", "1000000000000000000",
+			packed("0000000000000001", "0000000000000001", "0000000000000004"),
+			packed(resourceID, recipient, "0000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+		},
+		{
+			"big nonce", 0x0102030405060708, "0000000000000004", "0000000000000001", "1",
+			packed("0102030405060708", "0000000000000004", "0000000000000001"),
+			packed(resourceID, recipient, "0000000000000000000000000000000000000000000000000000000000000001"),
+		},
+		{
+			"zero amount", 7, "0000000000000001", "0000000000000004", "0",
+			packed("0000000000000007", "0000000000000001", "0000000000000004"),
+			packed(resourceID, recipient, "0000000000000000000000000000000000000000000000000000000000000000"),
+		},
+		{
+			"amount of 32 bytes", 7, "0000000000000001", "0000000000000004",
+			"115792089237316195423570985008687907853269984665640564039457584007913129639935",
+			packed("0000000000000007", "0000000000000001", "0000000000000004"),
+			packed(resourceID, recipient, "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			amount, _ := new(big.Int).SetString(tt.amount, 10)
+			nonceKey, dataKey := ProposalKeys(tt.nonce, BytesToBytes8(common.FromHex(tt.origin)), BytesToBytes8(common.FromHex(tt.destination)),
+				BytesToBytes32(common.FromHex(resourceID)), common.HexToAddress(recipient), amount)
+			if nonceKey != tt.wantNonceKey {
+				t.Errorf("nonce key %x, want %x", nonceKey, tt.wantNonceKey)
+			}
+			if dataKey != tt.wantDataKey {
+				t.Errorf("data key %x, want %x", dataKey, tt.wantDataKey)
+			}
+		})
+	}
+}
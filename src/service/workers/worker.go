@@ -4,6 +4,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/latoken/bridge-backend-service/src/models"
 	"github.com/latoken/bridge-backend-service/src/service/storage"
@@ -17,20 +18,38 @@ type IWorker interface {
 	GetDestinationID() string
 	// GetWokrerAddress returns worker address
 	GetWorkerAddress() string
+	// GetWorkerBalance returns native balance of worker address in wei
+	GetWorkerBalance() (*big.Int, error)
 	// GetStartHeight returns blockchain start height for watcher
 	GetStartHeight() (int64, error)
 	// GetConfirmNum returns numbers of blocks after them tx will be confirmed
 	GetConfirmNum() int64
+	// GetBlockHistory returns numbers of last block headers kept in database
+	GetBlockHistory() int64
 	// GetHeight returns current height of chain
 	GetHeight() (int64, error)
+	// GetBlockHash returns hash of the block at height
+	GetBlockHash(height int64) (string, error)
 	// GetBlockAndTxs returns block info and txs included in this block
 	GetBlockAndTxs(height int64) (*models.BlockAndTxLogs, error)
+	// GetScanStatus returns progress of logs scanning, number of blocks behind the chain
+	GetScanStatus() models.ScanStatus
+	// ReparseLog parses quarantined log again into tx
+	ReparseLog(quarantinedLog *storage.QuarantinedLog) (*storage.TxLog, error)
+	// SubscribeBlockAndTxs sends block info and txs of every new block into ch until subscription drops
+	SubscribeBlockAndTxs(ch chan<- *models.BlockAndTxLogs) (ethereum.Subscription, error)
 	// GetFetchInterval returns fetch interval of the chain like average blocking time, it is used in observer
 	GetFetchInterval() time.Duration
 	GetGasPrice() float64
 	GetConfig() *models.WorkerConfig
+	// ReplaceTx re-sends pending tx with the same nonce and bumped gas price
+	ReplaceTx(hash string) (string, error)
+	// IsTxNonceUsed returns true if nonce of the tx is taken by a confirmed tx, so the tx can't be mined anymore
+	IsTxNonceUsed(hash string) (bool, error)
 	// gets tx status from chain
 	GetSentTxStatus(hash string) storage.TxStatus
+	// GetRevertReason returns decoded revert reason of failed tx
+	GetRevertReason(hash string) (string, error)
 	GetStatus() (*models.WorkerStatus, error)
 	// IsSameAddress returns is addrA the same with addrB
 	IsSameAddress(addrA string, addrB string) bool
@@ -38,10 +57,14 @@ type IWorker interface {
 	ExecuteProposalEth(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string) (string, error)
 	//Executes Swap on Lachain
 	ExecuteProposalLa(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string, bytes []byte) (string, error)
+	//gets on-chain status of the proposal from bridge contract
+	GetProposalStatus(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, amount string) (storage.ProposalStatus, error)
 	//to get Liquidity Index for aave tokens
 	GetLiquidityIndex(handlerAddress, usdtAddress common.Address) ([]byte, error)
 	//updates withdraw swap status on lachain
 	UpdateSwapStatusOnChain(depositNonce uint64, originChainID [8]byte, destinationChainID [8]byte, resourceID [32]byte, receiptAddr string, outAmount, inAmount *big.Int, bytes []byte, status uint8) (string, error)
+	//gets admin state of bridge contract: paused, owner, proxy implementation
+	GetContractState() (*storage.ContractState, error)
 	//gets decimals from token address by taking resource id
 	GetDecimalsFromResourceID(resourceID string) (uint8, error)
 }